@@ -1,69 +1,63 @@
 package d01
 
 import (
-	"bufio"
+	"context"
 	_ "embed"
+	"errors"
 	"io"
-	"log"
 	"strconv"
-	"strings"
 
 	"github.com/matthinz/aoc-golang"
+	"github.com/matthinz/aoc-golang/parse"
 )
 
 //go:embed input
 var defaultInput string
 
+//go:embed answers.json
+var answers string
+
+func init() {
+	aoc.Register(2020, New())
+}
+
 func New() aoc.Day {
-	return aoc.NewDay(1, defaultInput, Puzzle1, Puzzle2)
+	return aoc.NewDay(1, defaultInput, Puzzle1, Puzzle2).WithAnswers(answers)
 }
 
-func Puzzle1(r io.Reader, l *log.Logger) string {
-	numbers := parseInput(r)
+func Puzzle1(ctx context.Context, r io.Reader, l *aoc.Logger) (string, error) {
+	numbers, err := parse.Ints(r)
+	if err != nil {
+		return "", err
+	}
 
 	for i := 0; i < len(numbers); i++ {
 		for j := i + 1; j < len(numbers); j++ {
 			if numbers[i]+numbers[j] == 2020 {
-				return strconv.Itoa(numbers[i] * numbers[j])
+				return strconv.Itoa(numbers[i] * numbers[j]), nil
 			}
 		}
 	}
 
-	return ""
+	return "", errors.New("no two entries sum to 2020")
 }
 
-func Puzzle2(r io.Reader, l *log.Logger) string {
-	numbers := parseInput(r)
+func Puzzle2(ctx context.Context, r io.Reader, l *aoc.Logger) (string, error) {
+	numbers, err := parse.Ints(r)
+	if err != nil {
+		return "", err
+	}
 
 	for i := 0; i < len(numbers); i++ {
 		for j := i + 1; j < len(numbers); j++ {
 			for k := i + 1; k < len(numbers); k++ {
 				if numbers[i]+numbers[j]+numbers[k] == 2020 {
-					return strconv.Itoa(numbers[i] * numbers[j] * numbers[k])
+					return strconv.Itoa(numbers[i] * numbers[j] * numbers[k]), nil
 				}
 			}
 
 		}
 	}
 
-	return ""
-}
-
-func parseInput(r io.Reader) []int {
-	result := make([]int, 0)
-
-	s := bufio.NewScanner(r)
-	for s.Scan() {
-		line := strings.TrimSpace(s.Text())
-		if len(line) == 0 {
-			continue
-		}
-		parsed, err := strconv.ParseInt(line, 10, 32)
-		if err != nil {
-			panic(err)
-		}
-		result = append(result, int(parsed))
-	}
-
-	return result
+	return "", errors.New("no three entries sum to 2020")
 }
@@ -1,21 +1,22 @@
 package d02
 
 import (
-	"bufio"
+	"context"
 	_ "embed"
-	"fmt"
 	"io"
-	"log"
 	"regexp"
 	"strconv"
-	"strings"
 
 	"github.com/matthinz/aoc-golang"
+	"github.com/matthinz/aoc-golang/parse"
 )
 
 //go:embed input
 var defaultInput string
 
+//go:embed answers.json
+var answers string
+
 type policy struct {
 	char rune
 	a    int
@@ -27,23 +28,33 @@ type input struct {
 	password string
 }
 
+func init() {
+	aoc.Register(2020, New())
+}
+
 func New() aoc.Day {
-	return aoc.NewDay(2, defaultInput, Puzzle1, Puzzle2)
+	return aoc.NewDay(2, defaultInput, Puzzle1, Puzzle2).WithAnswers(answers)
 }
 
-func Puzzle1(r io.Reader, l *log.Logger) string {
-	inputs := parseInput(r)
+func Puzzle1(ctx context.Context, r io.Reader, l *aoc.Logger) (string, error) {
+	inputs, err := parseInput(r)
+	if err != nil {
+		return "", err
+	}
 	valid := 0
 	for _, i := range inputs {
 		if i.isValidForSledRentalPlace() {
 			valid++
 		}
 	}
-	return strconv.Itoa(valid)
+	return strconv.Itoa(valid), nil
 }
 
-func Puzzle2(r io.Reader, l *log.Logger) string {
-	inputs := parseInput(r)
+func Puzzle2(ctx context.Context, r io.Reader, l *aoc.Logger) (string, error) {
+	inputs, err := parseInput(r)
+	if err != nil {
+		return "", err
+	}
 	valid := 0
 	for _, i := range inputs {
 		if i.isValidForOTCA() {
@@ -51,7 +62,7 @@ func Puzzle2(r io.Reader, l *log.Logger) string {
 		}
 	}
 
-	return strconv.Itoa(valid)
+	return strconv.Itoa(valid), nil
 }
 
 func (i *input) isValidForOTCA() bool {
@@ -77,34 +88,40 @@ func (i *input) isValidForSledRentalPlace() bool {
 	return count >= min && count <= max
 }
 
-func parseInput(r io.Reader) []input {
-	result := make([]input, 0)
-	rx := regexp.MustCompile("^(\\d+)-(\\d+) (\\w): (.+)$")
+var inputPattern = regexp.MustCompile(`^(\d+)-(\d+) (\w): (.+)$`)
 
-	s := bufio.NewScanner(r)
-	for s.Scan() {
-		line := strings.TrimSpace(s.Text())
-		if len(line) == 0 {
-			continue
+func parseInput(r io.Reader) ([]input, error) {
+	records, err := parse.Records(r, inputPattern)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]input, 0, len(records))
+
+	for _, record := range records {
+		a, err := record.Int(0)
+		if err != nil {
+			return nil, err
 		}
 
-		m := rx.FindStringSubmatch(line)
-		if m == nil {
-			panic(fmt.Sprintf("Not matched: %s", line))
+		b, err := record.Int(1)
+		if err != nil {
+			return nil, err
 		}
 
-		min, _ := strconv.ParseInt(m[1], 10, 32)
-		max, _ := strconv.ParseInt(m[2], 10, 32)
+		if a < 1 || b < a {
+			return nil, record.Errorf(0, "invalid range %d-%d", a, b)
+		}
 
 		result = append(result, input{
-			password: m[4],
+			password: record.Fields[3],
 			policy: policy{
-				char: rune(m[3][0]),
-				a:    int(min),
-				b:    int(max),
+				char: rune(record.Fields[2][0]),
+				a:    a,
+				b:    b,
 			},
 		})
 	}
 
-	return result
+	return result, nil
 }
@@ -1,64 +1,8 @@
-package y2021
+// Package y2020 imports every 2020 puzzle, registering them with the aoc
+// package. Adding a day means adding its package and an import here.
+package y2020
 
 import (
-	aoc "github.com/matthinz/aoc-golang"
-	d01 "github.com/matthinz/aoc-golang/2020/01"
-	d02 "github.com/matthinz/aoc-golang/2020/02"
-	/*
-		d03 "github.com/matthinz/aoc-golang/2020/03"
-		d04 "github.com/matthinz/aoc-golang/2020/04"
-		d05 "github.com/matthinz/aoc-golang/2020/05"
-		d06 "github.com/matthinz/aoc-golang/2020/06"
-		d07 "github.com/matthinz/aoc-golang/2020/07"
-		d08 "github.com/matthinz/aoc-golang/2020/08"
-		d09 "github.com/matthinz/aoc-golang/2020/09"
-		d10 "github.com/matthinz/aoc-golang/2020/10"
-		d11 "github.com/matthinz/aoc-golang/2020/11"
-		d12 "github.com/matthinz/aoc-golang/2020/12"
-		d13 "github.com/matthinz/aoc-golang/2020/13"
-		d14 "github.com/matthinz/aoc-golang/2020/14"
-		d15 "github.com/matthinz/aoc-golang/2020/15"
-		d16 "github.com/matthinz/aoc-golang/2020/16"
-		d17 "github.com/matthinz/aoc-golang/2020/17"
-		d18 "github.com/matthinz/aoc-golang/2020/18"
-		d19 "github.com/matthinz/aoc-golang/2020/19"
-		d20 "github.com/matthinz/aoc-golang/2020/20"
-		d21 "github.com/matthinz/aoc-golang/2020/21"
-		d22 "github.com/matthinz/aoc-golang/2020/22"
-		d23 "github.com/matthinz/aoc-golang/2020/23"
-		d24 "github.com/matthinz/aoc-golang/2020/24"
-		d25 "github.com/matthinz/aoc-golang/2020/25"
-	*/)
-
-func New() aoc.Year {
-	return aoc.NewYear(
-		2020,
-		d01.New(),
-		d02.New(),
-		/*
-			d03.New(),
-			d04.New(),
-			d05.New(),
-			d06.New(),
-			d07.New(),
-			d08.New(),
-			d09.New(),
-			d10.New(),
-			d11.New(),
-			d12.New(),
-			d13.New(),
-			d14.New(),
-			d15.New(),
-			d16.New(),
-			d17.New(),
-			d18.New(),
-			d19.New(),
-			d20.New(),
-			d21.New(),
-			d22.New(),
-			d23.New(),
-			d24.New(),
-			d25.New(),
-		*/
-	)
-}
+	_ "github.com/matthinz/aoc-golang/2020/01"
+	_ "github.com/matthinz/aoc-golang/2020/02"
+)
@@ -1,110 +1,78 @@
 package d01
 
 import (
-	"bufio"
+	"context"
 	_ "embed"
 	"io"
-	"log"
 	"strconv"
 	"strings"
 
 	"github.com/matthinz/aoc-golang"
+	"github.com/matthinz/aoc-golang/parse"
 )
 
 //go:embed input
 var defaultInput string
 
-func New() aoc.Day {
-	return aoc.NewDay(1, defaultInput, Puzzle1, Puzzle2)
+//go:embed answers.json
+var answers string
+
+// exampleInput is the example from the puzzle description
+const exampleInput = `
+199
+200
+208
+210
+200
+207
+240
+269
+260
+263
+`
+
+func init() {
+	aoc.Register(2021, New())
 }
 
-func Puzzle1(r io.Reader, l *log.Logger) string {
-
-	s := bufio.NewScanner(r)
-
-	var prevValue *int
-	var increases int
-
-	for s.Scan() {
-		valueAsInt64, err := strconv.ParseInt(s.Text(), 10, 32)
-		if err != nil {
-			continue
-		}
-
-		value := int(valueAsInt64)
-
-		if prevValue != nil {
-
-			increased := value > *prevValue
-			decreased := value < *prevValue
-
-			if increased {
-				increases++
-				log.Printf("%d: increased\n", valueAsInt64)
-			} else if decreased {
-				log.Printf("%d: decreased\n", valueAsInt64)
-			}
-
-		}
+func New() aoc.Day {
+	return aoc.NewDay(1, defaultInput, Puzzle1, Puzzle2).
+		WithAnswers(answers).
+		WithExample(exampleInput, "7", "5")
+}
 
-		prevValue = &value
-	}
+func Puzzle1(ctx context.Context, r io.Reader, l *aoc.Logger) (string, error) {
+	// each reading compared to the one before it
+	return countIncreases(r, NewWindow(1, 1), l)
+}
 
-	return strconv.Itoa(increases)
+func Puzzle2(ctx context.Context, r io.Reader, l *aoc.Logger) (string, error) {
+	// each three-reading sum compared to the one starting a reading earlier
+	return countIncreases(r, NewWindow(3, 1), l)
 }
 
-func Puzzle2(r io.Reader, l *log.Logger) string {
-	const WindowSize = 3
+// countIncreases streams readings from r through w and returns how many times
+// the window's sum increased
+func countIncreases(r io.Reader, w *Window, l *aoc.Logger) (string, error) {
+	debug := l.Enabled(aoc.LevelDebug)
 
-	scanner := bufio.NewScanner(r)
-	var window []int
-	var prevSum *int
-	var increases int
+	err := parse.EachInt(r, func(value int) error {
+		change := w.Add(value)
 
-	for scanner.Scan() {
-		valueAsInt64, err := strconv.ParseInt(scanner.Text(), 10, 64)
-		if err != nil {
-			continue
+		if debug && w.Full() {
+			l.Debugf(
+				"%s = %d (%s), mean %.1f, min %d, max %d",
+				formatEquation(w.Values()), w.Sum(), change, w.Mean(), w.Min(), w.Max(),
+			)
 		}
 
-		value := int(valueAsInt64)
-
-		window = append(window, value)
-
-		if len(window) == WindowSize {
-
-			sum := sumValues(window)
-
-			if prevSum != nil {
-				increased := sum > *prevSum
-				decreased := sum < *prevSum
-
-				if increased {
-					log.Printf("%s = %d (increased)\n", formatEquation(window), sum)
-					increases++
-				} else if decreased {
-					log.Printf("%s = %d (decreased)\n", formatEquation(window), sum)
-				}
-			} else {
-				log.Printf("%s = %d (n/a)\n", formatEquation(window), sum)
-			}
-
-			prevSum = &sum
-
-			window = window[1:]
-		}
+		return nil
+	})
+	if err != nil {
+		return "", err
 	}
 
-	return strconv.Itoa(increases)
-
-}
-
-func sumValues(values []int) int {
-	var result int
-	for _, v := range values {
-		result += v
-	}
-	return result
+	return strconv.Itoa(w.Increases()), nil
 }
 
 func formatEquation(values []int) string {
@@ -0,0 +1,202 @@
+package d01
+
+import "fmt"
+
+// Change describes how a window's sum compares with the sum of the window
+// some number of readings before it
+type Change int
+
+const (
+	// there's no earlier window to compare with yet
+	NoChange Change = iota
+	Increased
+	Decreased
+	Unchanged
+)
+
+// Window computes statistics over the last few readings of a stream of sonar
+// sweeps, one reading at a time. It only ever holds on to as many readings as
+// it needs, so it uses the same memory no matter how long the stream runs.
+type Window struct {
+	size int
+	lag  int
+
+	// number of readings seen so far
+	count int
+
+	// the last size readings, as a ring buffer
+	readings []int
+
+	// the sums of the last lag full windows, as a ring buffer
+	sums []int
+
+	sum int
+
+	// readings that could yet become the smallest and largest in the window,
+	// oldest first
+	mins, maxes []entry
+
+	increases, decreases int
+}
+
+// entry is a reading along with its position in the stream
+type entry struct {
+	n     int
+	value int
+}
+
+// NewWindow returns a Window that covers size readings at a time, and
+// compares the sum of each full window with that of the window lag readings
+// before it
+func NewWindow(size, lag int) *Window {
+	if size < 1 || lag < 1 {
+		panic(fmt.Sprintf("invalid window size %d / lag %d", size, lag))
+	}
+
+	return &Window{
+		size:     size,
+		lag:      lag,
+		readings: make([]int, size),
+		sums:     make([]int, lag),
+	}
+}
+
+// Add slides the window along to include value, returning how the sum of the
+// window changed compared to lag readings ago
+func (w *Window) Add(value int) Change {
+	slot := w.count % w.size
+	if w.count >= w.size {
+		w.sum -= w.readings[slot]
+	}
+	w.readings[slot] = value
+	w.sum += value
+	w.count++
+
+	w.mins = slide(w.mins, entry{w.count, value}, w.count-w.size, func(a, b int) bool { return a >= b })
+	w.maxes = slide(w.maxes, entry{w.count, value}, w.count-w.size, func(a, b int) bool { return a <= b })
+
+	if !w.Full() {
+		return NoChange
+	}
+
+	// index of this full window, counting from 0
+	k := w.count - w.size
+	previous := w.sums[k%w.lag]
+	w.sums[k%w.lag] = w.sum
+
+	if k < w.lag {
+		return NoChange
+	}
+
+	switch {
+	case w.sum > previous:
+		w.increases++
+		return Increased
+	case w.sum < previous:
+		w.decreases++
+		return Decreased
+	}
+
+	return Unchanged
+}
+
+// slide adds e to the back of candidates, first dropping any readings that e
+// replaces (those for which replaced(theirs, e's) is true), then drops from
+// the front any readings at or before position oldest, which have left the
+// window
+func slide(candidates []entry, e entry, oldest int, replaced func(a, b int) bool) []entry {
+	for len(candidates) > 0 && replaced(candidates[len(candidates)-1].value, e.value) {
+		candidates = candidates[:len(candidates)-1]
+	}
+	candidates = append(candidates, e)
+
+	for candidates[0].n <= oldest {
+		candidates = candidates[1:]
+	}
+
+	return candidates
+}
+
+// Full returns whether the window holds size readings
+func (w *Window) Full() bool {
+	return w.count >= w.size
+}
+
+// Count returns the number of readings seen so far
+func (w *Window) Count() int {
+	return w.count
+}
+
+// Values returns the readings in the window, oldest first
+func (w *Window) Values() []int {
+	n := w.count
+	if n > w.size {
+		n = w.size
+	}
+
+	result := make([]int, n)
+	start := w.count - n
+	for i := range result {
+		result[i] = w.readings[(start+i)%w.size]
+	}
+
+	return result
+}
+
+// Sum returns the sum of the readings in the window
+func (w *Window) Sum() int {
+	return w.sum
+}
+
+// Mean returns the average of the readings in the window, or 0 if there are
+// none yet
+func (w *Window) Mean() float64 {
+	n := w.count
+	if n > w.size {
+		n = w.size
+	}
+	if n == 0 {
+		return 0
+	}
+	return float64(w.sum) / float64(n)
+}
+
+// Min returns the smallest reading in the window, or 0 if there are none yet
+func (w *Window) Min() int {
+	if len(w.mins) == 0 {
+		return 0
+	}
+	return w.mins[0].value
+}
+
+// Max returns the largest reading in the window, or 0 if there are none yet
+func (w *Window) Max() int {
+	if len(w.maxes) == 0 {
+		return 0
+	}
+	return w.maxes[0].value
+}
+
+// Increases returns how many times a full window's sum has been larger than
+// that of the window lag readings before it
+func (w *Window) Increases() int {
+	return w.increases
+}
+
+// Decreases returns how many times a full window's sum has been smaller than
+// that of the window lag readings before it
+func (w *Window) Decreases() int {
+	return w.decreases
+}
+
+func (c Change) String() string {
+	switch c {
+	case Increased:
+		return "increased"
+	case Decreased:
+		return "decreased"
+	case Unchanged:
+		return "unchanged"
+	}
+	return "n/a"
+}
@@ -0,0 +1,119 @@
+package d01
+
+import (
+	"reflect"
+	"testing"
+)
+
+var exampleReadings = []int{199, 200, 208, 210, 200, 207, 240, 269, 260, 263}
+
+func TestWindowIncreases(t *testing.T) {
+	tests := []struct {
+		size, lag int
+		increases int
+		decreases int
+	}{
+		{1, 1, 7, 2},
+		{3, 1, 5, 1},
+		// a 3-reading window compared to the one 3 readings earlier doesn't
+		// overlap it at all
+		{3, 3, 5, 0},
+		{1, 5, 5, 0},
+		{10, 1, 0, 0},
+	}
+
+	for _, test := range tests {
+		w := NewWindow(test.size, test.lag)
+		for _, value := range exampleReadings {
+			w.Add(value)
+		}
+
+		if w.Increases() != test.increases || w.Decreases() != test.decreases {
+			t.Errorf(
+				"size %d, lag %d: expected %d increases and %d decreases, got %d and %d",
+				test.size, test.lag, test.increases, test.decreases, w.Increases(), w.Decreases(),
+			)
+		}
+	}
+}
+
+func TestWindowChanges(t *testing.T) {
+	w := NewWindow(2, 1)
+
+	tests := []struct {
+		value    int
+		expected Change
+	}{
+		// the window isn't full yet
+		{5, NoChange},
+		// nothing to compare the first full window with
+		{6, NoChange},
+		{4, Decreased},
+		{7, Increased},
+		{4, Unchanged},
+	}
+
+	for i, test := range tests {
+		if actual := w.Add(test.value); actual != test.expected {
+			t.Errorf("%d: expected %s, got %s", i, test.expected, actual)
+		}
+	}
+}
+
+func TestWindowStats(t *testing.T) {
+	w := NewWindow(3, 1)
+
+	if w.Mean() != 0 || w.Min() != 0 || w.Max() != 0 || len(w.Values()) != 0 {
+		t.Errorf("Expected an empty window to have no stats")
+	}
+
+	for i, value := range exampleReadings {
+		w.Add(value)
+
+		start := i - 2
+		if start < 0 {
+			start = 0
+		}
+		window := exampleReadings[start : i+1]
+
+		sum, min, max := 0, window[0], window[0]
+		for _, v := range window {
+			sum += v
+			if v < min {
+				min = v
+			}
+			if v > max {
+				max = v
+			}
+		}
+
+		if !reflect.DeepEqual(w.Values(), window) {
+			t.Errorf("%d: expected values %v, got %v", i, window, w.Values())
+		}
+
+		if w.Sum() != sum || w.Min() != min || w.Max() != max {
+			t.Errorf("%d: expected sum %d, min %d, max %d, got %d, %d, %d", i, sum, min, max, w.Sum(), w.Min(), w.Max())
+		}
+
+		if mean := float64(sum) / float64(len(window)); w.Mean() != mean {
+			t.Errorf("%d: expected mean %f, got %f", i, mean, w.Mean())
+		}
+	}
+}
+
+func TestWindowMemory(t *testing.T) {
+	w := NewWindow(5, 2)
+
+	// a long, steadily falling stream is the worst case for tracking the min
+	for i := 0; i < 100000; i++ {
+		w.Add(-i)
+	}
+
+	if len(w.readings) != 5 || len(w.sums) != 2 || len(w.mins) > 5 || len(w.maxes) > 5 {
+		t.Errorf("Window grew: %d readings, %d sums, %d mins, %d maxes", len(w.readings), len(w.sums), len(w.mins), len(w.maxes))
+	}
+
+	if w.Min() != -99999 || w.Max() != -99995 || w.Count() != 100000 {
+		t.Errorf("Expected min -99999 and max -99995 after 100000, got %d and %d after %d", w.Min(), w.Max(), w.Count())
+	}
+}
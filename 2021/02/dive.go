@@ -1,12 +1,10 @@
 package d02
 
 import (
-	"bufio"
+	"context"
 	_ "embed"
 	"io"
-	"log"
 	"strconv"
-	"strings"
 
 	"github.com/matthinz/aoc-golang"
 )
@@ -14,75 +12,109 @@ import (
 //go:embed input
 var defaultInput string
 
-func New() aoc.Day {
-	return aoc.NewDay(2, defaultInput, Puzzle1, Puzzle2)
-}
+//go:embed answers.json
+var answers string
 
-func Puzzle1(r io.Reader, l *log.Logger) string {
-	var x int
-	var y int
+// exampleInput is the example from the puzzle description
+const exampleInput = `
+forward 5
+down 5
+forward 8
+up 3
+down 8
+forward 2
+`
 
-	s := bufio.NewScanner(r)
-	for s.Scan() {
-		line := s.Text()
-		tokens := strings.Split(line, " ")
-		if len(tokens) != 2 {
-			continue
-		}
-		command := tokens[0]
-		value, err := strconv.ParseInt(tokens[1], 10, 64)
-		if err != nil {
-			panic(err)
-		}
+// simple is the model from part 1, in which "down" and "up" change depth
+// directly
+var simple = NewModel("simple").
+	Handle("forward", func(p Position, value int) Position {
+		p.Horizontal += value
+		return p
+	}).
+	Handle("down", func(p Position, value int) Position {
+		p.Depth += value
+		return p
+	}).
+	Handle("up", func(p Position, value int) Position {
+		p.Depth -= value
+		return p
+	})
 
-		switch command {
-		case "forward":
-			x += int(value)
-			break
-		case "down":
-			y += int(value)
-			break
-		case "up":
-			y -= int(value)
-			break
-		}
+// aim is the model from part 2, in which "down" and "up" change the
+// submarine's aim, and moving forward follows it
+var aim = NewModel("aim").
+	Handle("forward", func(p Position, value int) Position {
+		p.Horizontal += value
+		p.Depth += p.Aim * value
+		return p
+	}).
+	Handle("down", func(p Position, value int) Position {
+		p.Aim += value
+		return p
+	}).
+	Handle("up", func(p Position, value int) Position {
+		p.Aim -= value
+		return p
+	})
 
-		l.Printf("%s %d: %d x %d = %d\n", command, value, x, y, x*y)
-	}
-	return strconv.Itoa(x * y)
+func init() {
+	RegisterModel(simple)
+	RegisterModel(aim)
+	aoc.Register(2021, New())
+}
+
+func New() aoc.Day {
+	return aoc.NewDay(2, defaultInput, Puzzle1, Puzzle2).
+		WithAnswers(answers).
+		WithExample(exampleInput, "150", "900")
+}
+
+func Puzzle1(ctx context.Context, r io.Reader, l *aoc.Logger) (string, error) {
+	return dive(ctx, simple, r, l)
 }
 
-func Puzzle2(r io.Reader, l *log.Logger) string {
-	var x int
-	var y int
-	var aim int
+func Puzzle2(ctx context.Context, r io.Reader, l *aoc.Logger) (string, error) {
+	return dive(ctx, aim, r, l)
+}
 
-	s := bufio.NewScanner(r)
+// dive follows the course in r using m and returns the product of where the
+// submarine ends up horizontally and its depth. If there is somewhere to
+// export to, the trace of every step is exported as trace.csv and trace.json.
+func dive(ctx context.Context, m *Model, r io.Reader, l *aoc.Logger) (string, error) {
+	trace, err := m.Run(r)
+	if err != nil {
+		return "", err
+	}
 
-	for s.Scan() {
-		line := s.Text()
-		tokens := strings.Split(line, " ")
-		if len(tokens) != 2 {
-			continue
+	if e := aoc.ExporterFrom(ctx); e != nil {
+		if err := exportTrace(e, "trace.csv", trace.WriteCSV); err != nil {
+			return "", err
 		}
-		command := tokens[0]
-		value, err := strconv.ParseInt(tokens[1], 10, 64)
-		if err != nil {
-			panic(err)
+		if err := exportTrace(e, "trace.json", trace.WriteJSON); err != nil {
+			return "", err
 		}
+	}
 
-		switch command {
-		case "forward":
-			x += int(value)
-			y += aim * int(value)
-			break
-		case "down":
-			aim += int(value)
-			break
-		case "up":
-			aim -= int(value)
-			break
-		}
+	for _, s := range trace {
+		l.Debugf("%s %d: %d x %d = %d (aim %d)", s.Command, s.Value, s.Horizontal, s.Depth, s.Horizontal*s.Depth, s.Aim)
 	}
-	return strconv.Itoa(x * y)
+
+	p := trace.Final()
+	return strconv.Itoa(p.Horizontal * p.Depth), nil
+}
+
+// exportTrace creates the named file using e and writes a trace to it
+func exportTrace(e aoc.Exporter, name string, write func(w io.Writer) error) error {
+	f, err := e.Create(name)
+	if err != nil {
+		return err
+	}
+
+	if err := write(f); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
 }
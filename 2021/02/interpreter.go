@@ -0,0 +1,170 @@
+package d02
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/matthinz/aoc-golang/parse"
+)
+
+// commandPattern matches a single command, e.g. "forward 5"
+var commandPattern = regexp.MustCompile(`^\s*(\S+)\s+(-?\d+)\s*$`)
+
+// models holds every registered Model, by name
+var models = map[string]*Model{}
+
+// Position is where the submarine is, and for models that use it, which way
+// it is pointing
+type Position struct {
+	Horizontal int `json:"horizontal"`
+	Depth      int `json:"depth"`
+	Aim        int `json:"aim"`
+}
+
+// Handler carries out a command with the given value, returning where the
+// submarine ends up
+type Handler func(p Position, value int) Position
+
+// Model is one interpretation of how the submarine responds to commands. Each
+// command it understands has a Handler.
+type Model struct {
+	name     string
+	handlers map[string]Handler
+}
+
+// Step is a single command that was carried out, and where it left the
+// submarine
+type Step struct {
+	Line    int    `json:"line"`
+	Command string `json:"command"`
+	Value   int    `json:"value"`
+	Position
+}
+
+// Trace is every step taken while following a course, in order
+type Trace []Step
+
+// NewModel returns a Model that doesn't understand any commands yet
+func NewModel(name string) *Model {
+	return &Model{name: name, handlers: map[string]Handler{}}
+}
+
+// RegisterModel makes m available from LookupModel. Registering two models
+// with the same name is a mistake.
+func RegisterModel(m *Model) {
+	if _, found := models[m.name]; found {
+		panic(fmt.Sprintf("model %q registered twice", m.name))
+	}
+	models[m.name] = m
+}
+
+// LookupModel returns the registered model with the given name
+func LookupModel(name string) (*Model, bool) {
+	m, found := models[name]
+	return m, found
+}
+
+// ModelNames returns the names of every registered model, sorted
+func ModelNames() []string {
+	names := make([]string, 0, len(models))
+	for name := range models {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Handle has m carry out command using h, replacing any handler it already
+// had for command, and returns m so that calls can be chained.
+func (m *Model) Handle(command string, h Handler) *Model {
+	m.handlers[command] = h
+	return m
+}
+
+func (m *Model) Name() string {
+	return m.name
+}
+
+// Run follows the course read from r, one command per line, starting from
+// the surface. Commands that m doesn't understand are errors that give the
+// line they were found on.
+func (m *Model) Run(r io.Reader) (Trace, error) {
+	records, err := parse.Records(r, commandPattern)
+	if err != nil {
+		return nil, err
+	}
+
+	trace := make(Trace, 0, len(records))
+	var p Position
+
+	for _, record := range records {
+		command := record.Fields[0]
+
+		h, found := m.handlers[command]
+		if !found {
+			return trace, record.Errorf(0, "unknown command %q for the %s model", command, m.name)
+		}
+
+		value, err := record.Int(1)
+		if err != nil {
+			return trace, err
+		}
+
+		p = h(p, value)
+
+		trace = append(trace, Step{
+			Line:     record.Line.Number,
+			Command:  command,
+			Value:    value,
+			Position: p,
+		})
+	}
+
+	return trace, nil
+}
+
+// Final returns where the submarine ended up, which is the surface if t is
+// empty
+func (t Trace) Final() Position {
+	if len(t) == 0 {
+		return Position{}
+	}
+	return t[len(t)-1].Position
+}
+
+// WriteCSV writes t to w as CSV with a header row, one row per step
+func (t Trace) WriteCSV(w io.Writer) error {
+	c := csv.NewWriter(w)
+
+	c.Write([]string{"line", "command", "value", "horizontal", "depth", "aim"})
+
+	for _, s := range t {
+		c.Write([]string{
+			strconv.Itoa(s.Line),
+			s.Command,
+			strconv.Itoa(s.Value),
+			strconv.Itoa(s.Horizontal),
+			strconv.Itoa(s.Depth),
+			strconv.Itoa(s.Aim),
+		})
+	}
+
+	c.Flush()
+	return c.Error()
+}
+
+// WriteJSON writes t to w as a JSON array with one object per step
+func (t Trace) WriteJSON(w io.Writer) error {
+	if t == nil {
+		t = Trace{}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(t)
+}
@@ -0,0 +1,131 @@
+package d02
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/matthinz/aoc-golang/parse"
+)
+
+func TestModels(t *testing.T) {
+	tests := []struct {
+		model    *Model
+		expected Position
+	}{
+		{simple, Position{Horizontal: 15, Depth: 10}},
+		{aim, Position{Horizontal: 15, Depth: 60, Aim: 10}},
+	}
+
+	for _, test := range tests {
+		trace, err := test.model.Run(strings.NewReader(exampleInput))
+		if err != nil {
+			t.Fatalf("%s: %s", test.model.Name(), err)
+		}
+
+		if len(trace) != 6 {
+			t.Errorf("%s: expected 6 steps, got %d", test.model.Name(), len(trace))
+		}
+
+		if actual := trace.Final(); actual != test.expected {
+			t.Errorf("%s: expected %+v, got %+v", test.model.Name(), test.expected, actual)
+		}
+	}
+}
+
+func TestRunUnknownCommand(t *testing.T) {
+	_, err := simple.Run(strings.NewReader("forward 5\n\n  backward 3\n"))
+
+	var parseErr *parse.Error
+	if !errors.As(err, &parseErr) {
+		t.Fatalf("Expected a *parse.Error, got %v", err)
+	}
+
+	expected := `line 3, column 3: unknown command "backward" for the simple model`
+	if err.Error() != expected {
+		t.Errorf("Expected %q, got %q", expected, err.Error())
+	}
+}
+
+func TestRunMalformedCommand(t *testing.T) {
+	_, err := aim.Run(strings.NewReader("down 2\nforward\n"))
+
+	var parseErr *parse.Error
+	if !errors.As(err, &parseErr) || parseErr.Line != 2 {
+		t.Errorf("Expected an error on line 2, got %v", err)
+	}
+}
+
+func TestCustomModel(t *testing.T) {
+	// a submarine with its controls wired backwards
+	backwards := NewModel("test-backwards").
+		Handle("forward", func(p Position, value int) Position {
+			p.Horizontal -= value
+			return p
+		}).
+		Handle("down", func(p Position, value int) Position {
+			p.Depth -= value
+			return p
+		})
+
+	RegisterModel(backwards)
+	defer delete(models, backwards.Name())
+
+	m, found := LookupModel("test-backwards")
+	if !found {
+		t.Fatalf("Model was not registered: %v", ModelNames())
+	}
+
+	trace, err := m.Run(strings.NewReader("forward 2\ndown 3\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if expected := (Position{Horizontal: -2, Depth: -3}); trace.Final() != expected {
+		t.Errorf("Expected %+v, got %+v", expected, trace.Final())
+	}
+
+	if _, err := m.Run(strings.NewReader("up 1\n")); err == nil {
+		t.Errorf("Expected the backwards model not to understand \"up\"")
+	}
+}
+
+func TestTraceExport(t *testing.T) {
+	trace, err := aim.Run(strings.NewReader("forward 5\ndown 5\nforward 8\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var csv bytes.Buffer
+	if err := trace.WriteCSV(&csv); err != nil {
+		t.Fatal(err)
+	}
+
+	expectedCSV := "line,command,value,horizontal,depth,aim\n" +
+		"1,forward,5,5,0,0\n" +
+		"2,down,5,5,0,5\n" +
+		"3,forward,8,13,40,5\n"
+	if csv.String() != expectedCSV {
+		t.Errorf("Expected CSV %q, got %q", expectedCSV, csv.String())
+	}
+
+	var buf bytes.Buffer
+	if err := trace.WriteJSON(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded Trace
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(decoded, trace) {
+		t.Errorf("Expected %+v, got %+v", trace, decoded)
+	}
+
+	if !strings.Contains(buf.String(), `"depth": 40`) {
+		t.Errorf("Expected positions to be flattened into each step: %s", buf.String())
+	}
+}
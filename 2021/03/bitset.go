@@ -0,0 +1,121 @@
+package d03
+
+import (
+	"math/big"
+	"math/bits"
+)
+
+// Report is a diagnostic report stored a column at a time: for each bit
+// position there is a bitset saying which lines have that bit set. Lines can
+// be any number of bits wide, and the lines still under consideration while
+// narrowing down a rating are a bitset too, so each step of narrowing is a
+// handful of big.Int operations rather than a pass over every line.
+type Report struct {
+	width  int
+	length int
+
+	// columns[c] has bit i set if line i has a 1 in column c. Column 0 is the
+	// leftmost, most significant, bit.
+	columns []*big.Int
+
+	// number of lines with a 1 in each column
+	ones []int
+}
+
+// newReport returns an empty report whose lines are width bits wide
+func newReport(width int) *Report {
+	r := &Report{
+		width:   width,
+		columns: make([]*big.Int, width),
+		ones:    make([]int, width),
+	}
+	for c := range r.columns {
+		r.columns[c] = new(big.Int)
+	}
+	return r
+}
+
+// add appends a line to r, given as the columns in which it has a 1
+func (r *Report) add(set []int) {
+	for _, c := range set {
+		r.columns[c].SetBit(r.columns[c], r.length, 1)
+		r.ones[c]++
+	}
+	r.length++
+}
+
+// Width returns how many bits wide each line is
+func (r *Report) Width() int {
+	return r.width
+}
+
+// Len returns the number of lines in r
+func (r *Report) Len() int {
+	return r.length
+}
+
+// Tally returns how many lines have a 1 in each column, from the left
+func (r *Report) Tally() []int {
+	result := make([]int, len(r.ones))
+	copy(result, r.ones)
+	return result
+}
+
+// All returns a set holding every line in r
+func (r *Report) All() *big.Int {
+	all := new(big.Int).Lsh(big.NewInt(1), uint(r.length))
+	return all.Sub(all, big.NewInt(1))
+}
+
+// Ones returns how many of the lines in candidates have a 1 in column
+func (r *Report) Ones(candidates *big.Int, column int) int {
+	return popCount(new(big.Int).And(candidates, r.columns[column]))
+}
+
+// MostAndLeastCommon returns the most and least common bits in column among
+// candidates. When 0 and 1 are equally common, 1 is the most common and 0 the
+// least. When every candidate has the same bit, it is both.
+func (r *Report) MostAndLeastCommon(candidates *big.Int, column int) (uint, uint) {
+	ones := r.Ones(candidates, column)
+	total := popCount(candidates)
+
+	switch {
+	case ones == 0:
+		return 0, 0
+	case ones == total:
+		return 1, 1
+	case ones*2 >= total:
+		return 1, 0
+	}
+
+	return 0, 1
+}
+
+// Filter returns the lines in candidates that have bit in column
+func (r *Report) Filter(candidates *big.Int, column int, bit uint) *big.Int {
+	result := new(big.Int)
+	if bit == 1 {
+		return result.And(candidates, r.columns[column])
+	}
+	return result.AndNot(candidates, r.columns[column])
+}
+
+// Line returns the value of line i
+func (r *Report) Line(i int) *big.Int {
+	value := new(big.Int)
+	for c, column := range r.columns {
+		if column.Bit(i) == 1 {
+			value.SetBit(value, r.width-1-c, 1)
+		}
+	}
+	return value
+}
+
+// popCount returns the number of bits set in x, which must not be negative
+func popCount(x *big.Int) int {
+	n := 0
+	for _, word := range x.Bits() {
+		n += bits.OnesCount(uint(word))
+	}
+	return n
+}
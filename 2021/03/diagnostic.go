@@ -1,54 +1,100 @@
 package d03
 
 import (
-	"bufio"
+	"context"
 	_ "embed"
 	"io"
-	"log"
-	"strconv"
+	"math/big"
 
 	"github.com/matthinz/aoc-golang"
+	"github.com/matthinz/aoc-golang/parse"
 )
 
 //go:embed input
 var defaultInput string
 
+//go:embed answers.json
+var answers string
+
+func init() {
+	aoc.Register(2021, New())
+}
+
 func New() aoc.Day {
-	return aoc.NewDay(3, defaultInput, Puzzle1, Puzzle2)
+	return aoc.NewDay(3, defaultInput, Puzzle1, Puzzle2).WithAnswers(answers)
 }
 
-func Puzzle1(r io.Reader, l *log.Logger) string {
-	numbers := parseInput(r)
+func Puzzle1(ctx context.Context, r io.Reader, l *aoc.Logger) (string, error) {
+	report, err := parseInput(r)
+	if err != nil {
+		return "", err
+	}
+
+	gamma, epsilon := calculateGammaAndEpsilon(report)
 
-	gamma, epsilon := calculateGammaAndEpsilon(numbers)
+	l.Debugf("gamma = %s, epsilon = %s", gamma, epsilon)
 
-	return strconv.Itoa(gamma * epsilon)
+	return new(big.Int).Mul(gamma, epsilon).String(), nil
 }
 
-func Puzzle2(r io.Reader, l *log.Logger) string {
-	numbers := parseInput(r)
+func Puzzle2(ctx context.Context, r io.Reader, l *aoc.Logger) (string, error) {
+	report, err := parseInput(r)
+	if err != nil {
+		return "", err
+	}
+
+	o2GeneratorRating, err := FindO2GeneratorRating(report)
+	if err != nil {
+		return "", err
+	}
 
-	o2GeneratorRating := FindO2GeneratorRating(numbers)
-	co2ScrubberRating := FindCo2ScrubberRating(numbers)
+	co2ScrubberRating, err := FindCo2ScrubberRating(report)
+	if err != nil {
+		return "", err
+	}
 
-	return strconv.Itoa(o2GeneratorRating * co2ScrubberRating)
+	l.Debugf("O2 generator rating = %s, CO2 scrubber rating = %s", o2GeneratorRating, co2ScrubberRating)
 
+	return new(big.Int).Mul(o2GeneratorRating, co2ScrubberRating).String(), nil
 }
 
-func parseInput(r io.Reader) []int {
-	scanner := bufio.NewScanner(r)
-	var result []int
+// parseInput reads one binary number per line into a Report. Every number
+// must have the same number of digits, however many that is.
+func parseInput(r io.Reader) (*Report, error) {
+	lines, err := parse.Lines(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(lines) == 0 {
+		return newReport(0), nil
+	}
+
+	text, _ := lines[0].Trimmed()
+	report := newReport(len(text))
+
+	var set []int
 
-	for scanner.Scan() {
-		token := scanner.Text()
-		if len(token) == 0 {
-			continue
+	for _, line := range lines {
+		text, offset := line.Trimmed()
+
+		if len(text) != report.Width() {
+			return nil, line.Errorf(offset, "expected %d digits, got %d", report.Width(), len(text))
 		}
-		value, err := strconv.ParseInt(token, 2, 64)
-		if err != nil {
-			panic(err)
+
+		set = set[:0]
+		for c := 0; c < len(text); c++ {
+			switch text[c] {
+			case '1':
+				set = append(set, c)
+			case '0':
+			default:
+				return nil, line.Errorf(offset+c, "expected a binary digit, got %q", text[c])
+			}
 		}
-		result = append(result, int(value))
+
+		report.add(set)
 	}
-	return result
+
+	return report, nil
 }
@@ -1,136 +1,42 @@
 package d03
 
 import (
-	"bufio"
-	"io"
-	"os"
-	"strconv"
+	"errors"
+	"math/big"
 )
 
-const MaxBitLength = 16
-
-func FindCo2ScrubberRating(numbers []int) int {
-	candidates := numbers
-	pos := MaxBitLength
-
-	for {
-		_, leastCommonBit := FindMostAndLeastCommonBits(candidates, pos)
-		candidates = FilterNumbersByBitAtPosition(candidates, leastCommonBit, pos)
-
-		switch len(candidates) {
-		case 0:
-			panic("Ran out of candidates!")
-		case 1:
-			return candidates[0]
-		}
-
-		pos--
-	}
-}
-
-func FindO2GeneratorRating(numbers []int) int {
-	candidates := numbers
-	pos := MaxBitLength
-
-	for {
-
-		mostCommonBit, _ := FindMostAndLeastCommonBits(candidates, pos)
-		candidates = FilterNumbersByBitAtPosition(candidates, mostCommonBit, pos)
-
-		switch len(candidates) {
-		case 0:
-			panic("Ran out of candidates!")
-		case 1:
-			return candidates[0]
-		}
-
-		pos--
-	}
-
+// FindCo2ScrubberRating narrows r down to the one line that has the least
+// common bit in each column, working from the left
+func FindCo2ScrubberRating(r *Report) (*big.Int, error) {
+	return findRating(r, func(mostCommon, leastCommon uint) uint {
+		return leastCommon
+	})
 }
 
-func FilterNumbersByBitAtPosition(numbers []int, bit int, pos int) []int {
-	result := make([]int, 0, len(numbers))
-	mask := 1 << pos
-
-	for _, value := range numbers {
-
-		var isMatch bool
-
-		if bit == 0 {
-			// check the bit is _not set_ at the position
-			isMatch = value&mask == 0
-		} else {
-			// check the bit is set at the p
-			isMatch = value&mask != 0
-		}
-
-		if isMatch {
-			result = append(result, value)
-		}
-	}
-
-	return result
+// FindO2GeneratorRating narrows r down to the one line that has the most
+// common bit in each column, working from the left
+func FindO2GeneratorRating(r *Report) (*big.Int, error) {
+	return findRating(r, func(mostCommon, leastCommon uint) uint {
+		return mostCommon
+	})
 }
 
-func FindMostAndLeastCommonBits(numbers []int, position int) (int, int) {
-
-	mask := 1 << position
-	setCount := 0
-
-	for _, value := range numbers {
-		bitIsSet := value&mask != 0
-		if bitIsSet {
-			setCount++
-		}
-	}
-
-	if setCount == 0 {
-		// no numbers have this bit set, so just ignore
-		return 0, 0
-	}
-
-	half := float64(len(numbers)) / 2.0
-
-	if float64(setCount) == half {
-		// when equally common, we say "1" for most common and "0" for least common
-		return 1, 0
-	}
-
-	var mostCommon int
-	var leastCommon int
-
-	if float64(setCount) >= half {
-		// when >= 50% are 1, we call 1 the most common
-		mostCommon = 1
+// findRating keeps only the lines that have the bit chosen by pick in each
+// column in turn, until there's just one left. Lines that are the same can't
+// be told apart, so if more than one is left at the end, they all have the
+// same value.
+func findRating(r *Report, pick func(mostCommon, leastCommon uint) uint) (*big.Int, error) {
+	if r.Len() == 0 {
+		return nil, errors.New("report is empty")
 	}
 
-	if float64(setCount) < half {
-		// when < 50% are 1, we call 1 the least common
-		leastCommon = 1
-	}
-
-	return mostCommon, leastCommon
-}
-
-func readNumbers(r io.Reader) []int {
-	scanner := bufio.NewScanner(os.Stdin)
-	var result []int
-
-	for scanner.Scan() {
-		token := scanner.Text()
-		if len(token) == 0 {
-			continue
-		}
-
-		value, err := strconv.ParseInt(token, 2, 64)
-
-		if err != nil {
-			panic(err)
-		}
+	candidates := r.All()
 
-		result = append(result, int(value))
+	for column := 0; column < r.Width() && popCount(candidates) > 1; column++ {
+		bit := pick(r.MostAndLeastCommon(candidates, column))
+		candidates = r.Filter(candidates, column, bit)
 	}
 
-	return result
+	// the lowest-numbered line still standing
+	return r.Line(int(candidates.TrailingZeroBits())), nil
 }
@@ -2,11 +2,27 @@ package d03
 
 import (
 	"fmt"
+	"math/big"
 	"strconv"
 	"strings"
 	"testing"
 )
 
+var exampleLines = []string{
+	"00100",
+	"11110",
+	"10110",
+	"10111",
+	"10101",
+	"01111",
+	"00111",
+	"11100",
+	"10000",
+	"11001",
+	"00010",
+	"01010",
+}
+
 func TestParsingWorksHowIThink(t *testing.T) {
 	value, err := strconv.ParseInt("01010", 2, 64)
 	if err != nil {
@@ -18,56 +34,58 @@ func TestParsingWorksHowIThink(t *testing.T) {
 }
 
 func TestFindO2GeneratorRating(t *testing.T) {
-	input := parseSliceOfBinaryNumbers(
-		[]string{
-			"00100",
-			"11110",
-			"10110",
-			"10111",
-			"10101",
-			"01111",
-			"00111",
-			"11100",
-			"10000",
-			"11001",
-			"00010",
-			"01010",
-		})
-
-	actual := FindO2GeneratorRating(input)
-	expected := 23
-	if actual != expected {
+	input := parseSliceOfBinaryNumbers(t, exampleLines)
+
+	actual, err := FindO2GeneratorRating(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := big.NewInt(23)
+	if actual.Cmp(expected) != 0 {
 		t.Error(fmt.Sprintf("Got the wrong rating! Expected %d (%b), got %d (%b)", expected, expected, actual, actual))
 	}
 }
 
 func TestFindCo2ScrubberRating(t *testing.T) {
-	input := parseSliceOfBinaryNumbers(
-		[]string{
-			"00100",
-			"11110",
-			"10110",
-			"10111",
-			"10101",
-			"01111",
-			"00111",
-			"11100",
-			"10000",
-			"11001",
-			"00010",
-			"01010",
-		},
-	)
+	input := parseSliceOfBinaryNumbers(t, exampleLines)
 
-	actual := FindCo2ScrubberRating(input)
-	expected := 10
-	if actual != expected {
+	actual, err := FindCo2ScrubberRating(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := big.NewInt(10)
+	if actual.Cmp(expected) != 0 {
 		t.Error(fmt.Sprintf("Got the wrong rating! Expected %d (%b), got %d (%b)", expected, expected, actual, actual))
 	}
 }
 
+func TestFindRatingsWithDuplicates(t *testing.T) {
+	// once every candidate is the same, there's nothing left to narrow down
+	input := parseSliceOfBinaryNumbers(t, []string{"110", "110", "001"})
+
+	o2, err := FindO2GeneratorRating(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	co2, err := FindCo2ScrubberRating(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if o2.Int64() != 6 || co2.Int64() != 1 {
+		t.Errorf("Expected 6 and 1, got %s and %s", o2, co2)
+	}
+}
+
+func TestFindRatingEmpty(t *testing.T) {
+	if _, err := FindO2GeneratorRating(newReport(5)); err == nil {
+		t.Errorf("Expected an error for an empty report")
+	}
+}
+
 func TestFindMostAndLeastCommonBits(t *testing.T) {
 	input := parseSliceOfBinaryNumbers(
+		t,
 		[]string{
 			"1001",
 			"1010",
@@ -76,34 +94,41 @@ func TestFindMostAndLeastCommonBits(t *testing.T) {
 		},
 	)
 
-	expectedMostCommon := []int{
-		1,
+	// by column, from the left
+	expectedMostCommon := []uint{
 		1,
 		0,
 		1,
+		1,
 	}
 
-	expectedLeastCommon := []int{
-		0,
+	expectedLeastCommon := []uint{
 		0,
 		1,
 		0,
+		0,
 	}
 
 	for i := 0; i < 4; i++ {
-		mostCommon, leastCommon := FindMostAndLeastCommonBits(input, i)
+		mostCommon, leastCommon := input.MostAndLeastCommon(input.All(), i)
 
 		if mostCommon != expectedMostCommon[i] {
-			t.Error(fmt.Sprintf("mostCommon should be %d at position %d", expectedMostCommon[i], i))
+			t.Error(fmt.Sprintf("mostCommon should be %d in column %d", expectedMostCommon[i], i))
 		}
 		if leastCommon != expectedLeastCommon[i] {
-			t.Error(fmt.Sprintf("leastCommon should be %d at position %d", expectedLeastCommon[i], i))
+			t.Error(fmt.Sprintf("leastCommon should be %d in column %d", expectedLeastCommon[i], i))
 		}
 	}
+
+	// among just the first two lines, column 0 is all 1s
+	if most, least := input.MostAndLeastCommon(big.NewInt(0b11), 0); most != 1 || least != 1 {
+		t.Errorf("Expected 1 to be both most and least common, got %d and %d", most, least)
+	}
 }
 
 func TestFilter(t *testing.T) {
 	input := parseSliceOfBinaryNumbers(
+		t,
 		[]string{
 			"1001",
 			"1010",
@@ -112,52 +137,116 @@ func TestFilter(t *testing.T) {
 		},
 	)
 
-	actual := FilterNumbersByBitAtPosition(input, 1, 0)
-
-	expected := parseSliceOfBinaryNumbers(
-		[]string{
-			"1001",
-			"1111",
-		},
-	)
-
-	assertEqual(t, actual, expected)
+	actual := input.Filter(input.All(), 3, 1)
+	assertEqual(t, input, actual, []string{
+		"1001",
+		"1111",
+	})
 
-	actual = FilterNumbersByBitAtPosition(input, 0, 0)
-	expected = parseSliceOfBinaryNumbers([]string{
+	actual = input.Filter(input.All(), 3, 0)
+	assertEqual(t, input, actual, []string{
 		"1010",
 		"0010",
 	})
-	assertEqual(t, actual, expected)
 
-	actual = FilterNumbersByBitAtPosition(input, 0, 3)
-	expected = parseSliceOfBinaryNumbers([]string{
+	actual = input.Filter(input.All(), 0, 1)
+	assertEqual(t, input, actual, []string{
+		"1001",
+		"1010",
+		"1111",
+	})
+
+	// narrowing further
+	actual = input.Filter(actual, 1, 0)
+	assertEqual(t, input, actual, []string{
 		"1001",
 		"1010",
-		"0010",
 	})
 }
 
-func assertEqual(t *testing.T, actual []int, expected []int) {
+func TestWideReport(t *testing.T) {
+	// 200 bits is far too wide for any machine word
+	lines := []string{
+		"1" + strings.Repeat("0", 198) + "1",
+		"1" + strings.Repeat("1", 198) + "0",
+		"0" + strings.Repeat("0", 198) + "1",
+	}
+
+	input := parseSliceOfBinaryNumbers(t, lines)
+
+	if input.Width() != 200 {
+		t.Fatalf("Expected 200 bits, got %d", input.Width())
+	}
 
-	if len(actual) != len(expected) {
-		t.Error(actual)
-		return
+	tally := input.Tally()
+	if tally[0] != 2 || tally[100] != 1 || tally[199] != 2 {
+		t.Errorf("Wrong tally: %v", tally)
 	}
 
-	for i := 0; i < len(expected); i++ {
-		if actual[i] != expected[i] {
-			t.Error(fmt.Sprintf("%d: expected %d (%b), got %d (%b)", i, expected[i], expected[i], actual[i], actual[i]))
-			return
+	o2, err := FindO2GeneratorRating(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected, _ := new(big.Int).SetString(lines[1], 2)
+	if o2.Cmp(expected) != 0 {
+		t.Errorf("Expected %b, got %b", expected, o2)
+	}
+
+	gamma, epsilon := calculateGammaAndEpsilon(input)
+
+	// gamma and epsilon are each other's complement
+	all := new(big.Int).Lsh(big.NewInt(1), 200)
+	all.Sub(all, big.NewInt(1))
+	if sum := new(big.Int).Add(gamma, epsilon); sum.Cmp(all) != 0 {
+		t.Errorf("Expected gamma + epsilon to be all 1s, got %b", sum)
+	}
+	if gamma.IsUint64() {
+		t.Errorf("Expected gamma not to fit in 64 bits: %s", gamma)
+	}
+}
+
+func TestParseInputErrors(t *testing.T) {
+	tests := map[string]string{
+		"101\n11\n":  "line 2, column 1: expected 3 digits, got 2",
+		"101\n1x1\n": `line 2, column 2: expected a binary digit, got 'x'`,
+	}
+
+	for input, expected := range tests {
+		_, err := parseInput(strings.NewReader(input))
+		if err == nil || err.Error() != expected {
+			t.Errorf("%q: expected %q, got %v", input, expected, err)
 		}
 	}
+}
 
+// assertEqual checks that the lines of report in actual are those expected,
+// in order
+func assertEqual(t *testing.T, report *Report, actual *big.Int, expected []string) {
+	t.Helper()
+
+	var lines []string
+	for i := 0; i < report.Len(); i++ {
+		if actual.Bit(i) == 1 {
+			lines = append(lines, fmt.Sprintf("%0*b", report.Width(), report.Line(i)))
+		}
+	}
+
+	if strings.Join(lines, ",") != strings.Join(expected, ",") {
+		t.Errorf("Expected %v, got %v", expected, lines)
+	}
 }
 
-func parseSliceOfBinaryNumbers(input []string) []int {
-	return parseInput(
+func parseSliceOfBinaryNumbers(t *testing.T, input []string) *Report {
+	t.Helper()
+
+	report, err := parseInput(
 		strings.NewReader(
 			strings.Join(input, "\n"),
 		),
 	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return report
 }
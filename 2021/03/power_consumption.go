@@ -1,35 +1,22 @@
 package d03
 
-func calculateGammaAndEpsilon(numbers []int) (int, int) {
-	bitLength := 16
-	setBits := make([]int, bitLength)
+import "math/big"
 
-	// Create a map of bit index to number of elements that have the corresponding bit set
-	for _, value := range numbers {
-		for i := 0; i < bitLength; i++ {
-			mask := 1 << i
-			bitIsSet := value&mask != 0
-			if bitIsSet {
-				setBits[i]++
-			}
-		}
-	}
-
-	var gamma, epsilon int
+// calculateGammaAndEpsilon returns the gamma rate, made from the most common
+// bit in each column of r, and the epsilon rate, made from the least common
+func calculateGammaAndEpsilon(r *Report) (*big.Int, *big.Int) {
+	gamma := new(big.Int)
+	epsilon := new(big.Int)
 
-	for i := 0; i < bitLength; i++ {
-		countWithBitSet := setBits[i]
-
-		if countWithBitSet == 0 {
-			continue
-		}
+	for c, ones := range r.Tally() {
+		bit := r.Width() - 1 - c
 
-		if countWithBitSet >= len(numbers)/2 {
+		if ones*2 >= r.Len() {
 			// when >= 50% have bit set, set corresponding bit in gamma
-			gamma = gamma | 1<<i
+			gamma.SetBit(gamma, bit, 1)
 		} else {
 			// when < 50% have bit set, set corresponding bit in epsilon
-			epsilon = epsilon | 1<<i
+			epsilon.SetBit(epsilon, bit, 1)
 		}
 	}
 
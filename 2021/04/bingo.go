@@ -1,16 +1,15 @@
 package day04
 
 import (
-	"bufio"
+	"context"
 	_ "embed"
-	"fmt"
+	"errors"
 	"io"
-	"log"
-	"os"
 	"strconv"
 	"strings"
 
 	"github.com/matthinz/aoc-golang"
+	"github.com/matthinz/aoc-golang/parse"
 )
 
 type square struct {
@@ -26,10 +25,17 @@ type board struct {
 type game struct {
 	numbers []int
 	boards  []board
+
+	// how boards win, or nil for Standard
+	rule Rule
 }
 
 type solvedBoard struct {
 	board
+
+	// index in the draw order of the number that made this board win
+	draw int
+
 	lastNumberDrawn int
 	finalScore      int
 }
@@ -37,67 +43,113 @@ type solvedBoard struct {
 //go:embed input
 var defaultInput string
 
+//go:embed answers.json
+var answers string
+
+func init() {
+	aoc.Register(2021, New())
+}
+
 func New() aoc.Day {
-	return aoc.NewDay(4, defaultInput, Puzzle1, Puzzle2)
+	return aoc.NewDay(4, defaultInput, Puzzle1, Puzzle2).WithAnswers(answers)
 }
 
-func Puzzle1(r io.Reader, l *log.Logger) string {
-	game := NewGame(r)
+func Puzzle1(ctx context.Context, r io.Reader, l *aoc.Logger) (string, error) {
+	game, err := setUpGame(ctx, r, l)
+	if err != nil {
+		return "", err
+	}
+
 	solvedBoards := game.Run()
+	if len(solvedBoards) == 0 {
+		return "", errors.New("no board wins")
+	}
 
 	board := solvedBoards[0]
+	l.Debugf("board %d wins first on draw %d:\n%s", board.index, board.draw+1, board.String())
 
-	return strconv.Itoa(board.score())
+	return strconv.Itoa(board.score()), nil
 }
 
-func Puzzle2(r io.Reader, l *log.Logger) string {
-	game := NewGame(r)
+func Puzzle2(ctx context.Context, r io.Reader, l *aoc.Logger) (string, error) {
+	game, err := setUpGame(ctx, r, l)
+	if err != nil {
+		return "", err
+	}
+
 	solvedBoards := game.Run()
+	if len(solvedBoards) == 0 {
+		return "", errors.New("no board wins")
+	}
 
 	board := solvedBoards[len(solvedBoards)-1]
+	l.Debugf("board %d wins last on draw %d:\n%s", board.index, board.draw+1, board.String())
 
-	return strconv.Itoa(board.score())
+	return strconv.Itoa(board.score()), nil
 }
 
-func main() {
-	game := NewGame(os.Stdin)
-	solvedBoards := game.Run()
-
-	for _, sb := range solvedBoards {
-		fmt.Printf("Board: %d/%d\nScore:%d\n", sb.index, len(solvedBoards), sb.finalScore)
+// setUpGame reads the game from r and sets it up according to these options:
+//
+//	rule     how boards win (see ParseRule), instead of by the standard rule
+//	analyze  if true, log the draw on which each board wins
+//	rig      log an order to draw the numbers in that has this board (counting
+//	         from 1) win first
+func setUpGame(ctx context.Context, r io.Reader, l *aoc.Logger) (game, error) {
+	g, err := NewGame(r)
+	if err != nil {
+		return game{}, err
 	}
-}
 
-func (g *game) Run() []solvedBoard {
+	options := aoc.OptionsFrom(ctx)
 
-	var result []solvedBoard
-
-	unsolvedBoards := g.boards
-
-	for _, number := range g.numbers {
-		for i := 0; i < len(unsolvedBoards); i++ {
-			b := &unsolvedBoards[i]
-			b.markNumber(number)
+	if name, found := options.Lookup("rule"); found {
+		rule, err := ParseRule(name)
+		if err != nil {
+			return game{}, err
+		}
+		g = g.WithRule(rule)
+	}
 
-			if b.isSolved() {
+	analyze, err := options.Bool("analyze")
+	if err != nil {
+		return game{}, err
+	}
 
-				finalScore := b.sumOfUnmarkedSquares() * number
+	if analyze {
+		for i, draw := range g.WinningDraws() {
+			if draw < 0 {
+				l.Infof("board %d never wins", i+1)
+				continue
+			}
+			l.Infof("board %d wins on draw %d (%d)", i+1, draw+1, g.numbers[draw])
+		}
+	}
 
-				// fmt.Printf("%d: %d * %d = %d\n", b.index, b.sumOfUnmarkedSquares(), number, finalScore)
+	board, err := options.Int("rig", 0)
+	if err != nil {
+		return game{}, err
+	}
 
-				result = append(result, solvedBoard{
-					board:           *b,
-					lastNumberDrawn: number,
-					finalScore:      finalScore,
-				})
+	if board != 0 {
+		order, err := g.RigFor(board - 1)
+		if err != nil {
+			return game{}, err
+		}
 
-				unsolvedBoards = removeBoardAt(unsolvedBoards, i)
-				i--
-			}
+		numbers := make([]string, len(order))
+		for i, number := range order {
+			numbers[i] = strconv.Itoa(number)
 		}
+		l.Infof("board %d wins first if the numbers are drawn in this order: %s", board, strings.Join(numbers, ","))
 	}
 
-	return result
+	return g, nil
+}
+
+// WithRule returns a copy of g in which boards win according to rule
+func (g game) WithRule(rule Rule) game {
+	g.rule = rule
+	return g
 }
 
 func (b *board) String() string {
@@ -127,63 +179,6 @@ func (b *board) String() string {
 
 }
 
-func (b *board) markNumber(number int) {
-	for y := range b.squares {
-		for x := range b.squares[y] {
-			s := &b.squares[y][x]
-			if s.value == number {
-				s.marked = true
-			}
-		}
-	}
-}
-
-func (b *board) isSolved() bool {
-	if len(b.squares) == 0 {
-		return false
-	}
-
-	height := len(b.squares)
-	width := len(b.squares[0])
-
-	rowStates := make([]bool, height)
-	colStates := make([]bool, width)
-
-	// initialize state maps to true
-
-	for y := 0; y < height; y++ {
-		rowStates[y] = true
-	}
-
-	for x := 0; x < width; x++ {
-		colStates[x] = true
-	}
-
-	for y, row := range b.squares {
-		for x, square := range row {
-			rowStates[y] = rowStates[y] && square.marked
-			colStates[x] = colStates[x] && square.marked
-		}
-	}
-
-	var anyRows bool
-	var anyCols bool
-
-	for _, ok := range rowStates {
-		if ok {
-			anyRows = true
-		}
-	}
-
-	for _, ok := range colStates {
-		if ok {
-			anyCols = true
-		}
-	}
-
-	return anyRows || anyCols
-}
-
 func (b *solvedBoard) score() int {
 	return b.sumOfUnmarkedSquares() * b.lastNumberDrawn
 }
@@ -203,72 +198,61 @@ func (b *board) sumOfUnmarkedSquares() int {
 	return result
 }
 
-func NewGame(r io.Reader) game {
-
-	scanner := bufio.NewScanner(r)
-
-	var g *game
-	var b *board
-
-	for scanner.Scan() {
-		line := scanner.Text()
+// NewGame reads the numbers to draw, comma-separated on the first line, and
+// the boards that follow, separated by blank lines. Boards can be any size, so
+// long as each is rectangular.
+func NewGame(r io.Reader) (game, error) {
+	blocks, err := parse.Blocks(r)
+	if err != nil {
+		return game{}, err
+	}
 
-		if len(line) == 0 {
-			b = nil
-			continue
-		}
+	if len(blocks) == 0 {
+		return game{}, errors.New("input is empty")
+	}
 
-		if g == nil {
-			// first line of input = numbers
-			g = &game{
-				numbers: parseNumbers(line, ","),
-			}
-			continue
-		}
+	numbers, err := blocks[0][0].CommaInts()
+	if err != nil {
+		return game{}, err
+	}
 
-		// subsequent lines of numbers = board
-		if b == nil {
-			g.boards = append(g.boards, board{
-				index: len(g.boards) + 1,
-			})
-			b = &g.boards[len(g.boards)-1]
-		}
+	g := game{numbers: numbers}
 
-		b.squares = append(b.squares, parseRow(line))
+	// boards normally follow a blank line, but don't have to
+	boards := blocks[1:]
+	if len(blocks[0]) > 1 {
+		boards = append([][]parse.Line{blocks[0][1:]}, boards...)
 	}
 
-	return *g
-}
-
-func parseNumbers(input string, sep string) []int {
-	var result []int
+	for _, block := range boards {
+		b := board{index: len(g.boards) + 1}
 
-	for _, token := range strings.Split(input, sep) {
-		value, err := strconv.ParseInt(token, 10, 32)
-		if err == nil {
-			result = append(result, int(value))
+		for _, line := range block {
+			row, err := parseRow(line)
+			if err != nil {
+				return game{}, err
+			}
+			if len(b.squares) > 0 && len(row) != len(b.squares[0]) {
+				return game{}, line.Errorf(-1, "board %d: expected %d numbers, got %d", b.index, len(b.squares[0]), len(row))
+			}
+			b.squares = append(b.squares, row)
 		}
+
+		g.boards = append(g.boards, b)
 	}
 
-	return result
+	return g, nil
 }
 
-func parseRow(input string) []square {
-	var result []square
-	for _, value := range parseNumbers(input, " ") {
-		result = append(result, square{
-			value: value,
-		})
+func parseRow(line parse.Line) ([]square, error) {
+	values, err := line.Ints()
+	if err != nil {
+		return nil, err
 	}
-	return result
-}
 
-func removeBoardAt(boards []board, index int) []board {
-	var result []board
-	for i := range boards {
-		if i != index {
-			result = append(result, boards[i])
-		}
+	result := make([]square, len(values))
+	for i, value := range values {
+		result[i] = square{value: value}
 	}
-	return result
+	return result, nil
 }
@@ -17,7 +17,10 @@ func TestNewGame(t *testing.T) {
 7 8
 `
 
-	game := NewGame(strings.NewReader(input))
+	game, err := NewGame(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
 
 	assertIntSlicesEqual(t, []int{1, 2, 3, 4}, game.numbers)
 
@@ -25,8 +28,7 @@ func TestNewGame(t *testing.T) {
 
 }
 
-func TestRunGame(t *testing.T) {
-	input := `
+const exampleInput = `
 7,4,9,5,11,17,23,2,0,14,21,24,10,16,13,6,15,25,12,22,18,20,8,19,3,26,1
 
 22 13 17 11  0
@@ -48,7 +50,11 @@ func TestRunGame(t *testing.T) {
 2  0 12  3  7
 	`
 
-	game := NewGame(strings.NewReader(input))
+func TestRunGame(t *testing.T) {
+	game, err := NewGame(strings.NewReader(exampleInput))
+	if err != nil {
+		t.Fatal(err)
+	}
 
 	assertIntSlicesEqual(
 		t,
@@ -66,23 +72,216 @@ func TestRunGame(t *testing.T) {
 
 	firstWinner := solution[0]
 	if firstWinner.index != 3 {
-		fmt.Println(firstWinner.String())
+		t.Log(firstWinner.String())
 		t.Error("The wrong board won first")
 	}
 
 	lastWinner := solution[len(solution)-1]
 	if lastWinner.index != 2 {
-		fmt.Println(lastWinner.String())
+		t.Log(lastWinner.String())
 		t.Error("the wrong board was last to win")
 	}
 
 	if lastWinner.finalScore != 1924 {
-		fmt.Println(lastWinner.String())
+		t.Log(lastWinner.String())
 		t.Error("the last board had the wrong score")
 	}
 
 }
 
+func TestNewGameErrors(t *testing.T) {
+	tests := map[string]string{
+		"":                          "input is empty",
+		"1,x\n\n1 2\n3 4\n":         `line 1, column 3: invalid number "x"`,
+		"1,2\n\n1 2\n3 4 5\n":       "line 4: board 1: expected 2 numbers, got 3",
+		"1,2\n\n1  2\n3 x 4\n":      `line 4, column 3: invalid number "x"`,
+		"1,2\n\n1 2\n 3 4.5\n":      `line 4, column 4: invalid number "4.5"`,
+		"1,2\n1 2\n3 4\n\n5\n6 7\n": "line 6: board 2: expected 1 numbers, got 2",
+	}
+
+	for input, expected := range tests {
+		_, err := NewGame(strings.NewReader(input))
+		if err == nil || err.Error() != expected {
+			t.Errorf("%q: expected %q, got %v", input, expected, err)
+		}
+	}
+}
+
+func TestRules(t *testing.T) {
+	tests := []struct {
+		name          string
+		rule          Rule
+		width, height int
+		groups        int
+		size          int
+	}{
+		{"rows", Rows, 5, 3, 3, 5},
+		{"columns", Columns, 5, 3, 5, 3},
+		{"diagonals", Diagonals, 4, 4, 2, 4},
+		{"no diagonals on rectangles", Diagonals, 4, 3, 0, 0},
+		{"corners", Corners, 5, 5, 1, 4},
+		{"corners of a single row", Corners, 5, 1, 1, 2},
+		{"full card", FullCard, 3, 4, 1, 12},
+		// 3 rows of 3, 3 columns of 4, and 2 of each diagonal on a 5x4 board
+		{"3 in a row", InARow(3), 5, 4, 4*3 + 5*2 + 3*2 + 3*2, 3},
+		{"too long to fit", InARow(6), 5, 5, 0, 0},
+		{"standard", Standard, 5, 5, 10, 5},
+	}
+
+	for _, test := range tests {
+		groups := test.rule(test.width, test.height)
+		if len(groups) != test.groups {
+			t.Errorf("%s: expected %d groups, got %d", test.name, test.groups, len(groups))
+			continue
+		}
+		for _, group := range groups {
+			if len(group) != test.size {
+				t.Errorf("%s: expected groups of %d, got %v", test.name, test.size, group)
+				break
+			}
+			for _, p := range group {
+				if p.X < 0 || p.Y < 0 || p.X >= test.width || p.Y >= test.height {
+					t.Errorf("%s: %v is off the board", test.name, p)
+				}
+			}
+		}
+	}
+}
+
+func TestRuleVariants(t *testing.T) {
+	input := `
+5,1,9,3,7,2
+
+1 2 3
+4 5 6
+7 8 9
+`
+
+	tests := []struct {
+		name string
+		rule Rule
+		draw int
+	}{
+		// 1, 5, 9
+		{"diagonals", Diagonals, 2},
+		// 1, 9, 3, 7
+		{"corners", Corners, 4},
+		// 5, 1, 9 is already three in a row, diagonally
+		{"3 in a row", InARow(3), 2},
+		{"2 in a row", InARow(2), 1},
+		// 1, 2, 3 with the rows and columns
+		{"standard", Standard, 5},
+		{"full card", FullCard, -1},
+	}
+
+	for _, test := range tests {
+		game, err := NewGame(strings.NewReader(input))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		game = game.WithRule(test.rule)
+		draws := game.WinningDraws()
+		if draws[0] != test.draw {
+			t.Errorf("%s: expected to win on draw %d, got %d", test.name, test.draw, draws[0])
+		}
+	}
+}
+
+func TestParseRule(t *testing.T) {
+	tests := []struct {
+		name   string
+		groups int
+	}{
+		{"standard", 10},
+		{"corners", 1},
+		{"full-card", 1},
+		{"standard+diagonals", 12},
+		{"rows + corners", 6},
+		// 2 rows and 2 columns of 4, and 2 diagonals of 4 each way
+		{"4-in-a-row", 5*2 + 5*2 + 2*2 + 2*2},
+	}
+
+	for _, test := range tests {
+		rule, err := ParseRule(test.name)
+		if err != nil {
+			t.Errorf("%s: %s", test.name, err)
+			continue
+		}
+
+		if groups := rule(5, 5); len(groups) != test.groups {
+			t.Errorf("%s: expected %d groups, got %d", test.name, test.groups, len(groups))
+		}
+	}
+
+	for _, name := range []string{"", "bogus", "rows+bogus", "0-in-a-row", "x-in-a-row"} {
+		if _, err := ParseRule(name); err == nil {
+			t.Errorf("%q: expected an error", name)
+		}
+	}
+}
+
+func TestWinningDraws(t *testing.T) {
+	game, err := NewGame(strings.NewReader(exampleInput))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// board 3 wins on 24, board 1 on 16 and board 2 on 13
+	assertIntSlicesEqual(t, []int{13, 14, 11}, game.WinningDraws())
+}
+
+func TestRigFor(t *testing.T) {
+	game, err := NewGame(strings.NewReader(exampleInput))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := range game.boards {
+		order, err := game.RigFor(i)
+		if err != nil {
+			t.Errorf("board %d: %s", i+1, err)
+			continue
+		}
+
+		if len(order) != len(game.numbers) {
+			t.Errorf("board %d: expected all %d numbers to be drawn, got %d", i+1, len(game.numbers), len(order))
+		}
+
+		rigged := game
+		rigged.numbers = order
+
+		if winner := rigged.Run()[0]; winner.index != i+1 {
+			t.Errorf("board %d: board %d won first with %v", i+1, winner.index, order)
+		}
+	}
+
+	if _, err := game.RigFor(3); err == nil {
+		t.Errorf("Expected an error for a board that doesn't exist")
+	}
+}
+
+func TestRigForImpossible(t *testing.T) {
+	// every way board 1 could win also wins for board 2
+	input := `
+1,2,3,4
+
+1 2
+3 4
+
+2 1
+4 3
+`
+	game, err := NewGame(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := game.RigFor(0); err == nil {
+		t.Errorf("Expected board 1 not to be able to win first")
+	}
+}
+
 func assertIntSlicesEqual(t *testing.T, x []int, y []int) {
 	if len(x) != len(y) {
 		t.Error(fmt.Sprintf("Lengths don't match: %d vs %d", len(x), len(y)))
@@ -0,0 +1,259 @@
+package day04
+
+import (
+	"fmt"
+	"sort"
+)
+
+// placement is where a number appears: on which board (by position in
+// game.boards) and in which square (counting across each row in turn)
+type placement struct {
+	board  int
+	square int
+}
+
+// engine plays a game under a rule. Rather than checking every board after
+// each draw, it indexes where each number appears and keeps a count of how
+// many squares in each winning group are marked, so a draw only touches the
+// squares holding that number.
+type engine struct {
+	game *game
+
+	// where each number appears
+	index map[int][]placement
+
+	boards []boardState
+}
+
+// boardState tracks a single board during play
+type boardState struct {
+	width int
+
+	// the winning groups each square is part of
+	groupsBySquare [][]int
+
+	// number of squares in each group, and how many of them are marked
+	sizes  []int
+	counts []int
+
+	marked []bool
+	won    bool
+}
+
+func newEngine(g *game) *engine {
+	rule := g.rule
+	if rule == nil {
+		rule = Standard
+	}
+
+	e := &engine{
+		game:   g,
+		index:  map[int][]placement{},
+		boards: make([]boardState, len(g.boards)),
+	}
+
+	for i := range g.boards {
+		b := &g.boards[i]
+		height := len(b.squares)
+		width := 0
+		if height > 0 {
+			width = len(b.squares[0])
+		}
+
+		state := boardState{
+			width:          width,
+			groupsBySquare: make([][]int, width*height),
+			marked:         make([]bool, width*height),
+		}
+
+		for id, group := range rule(width, height) {
+			state.sizes = append(state.sizes, len(group))
+			state.counts = append(state.counts, 0)
+			for _, p := range group {
+				square := p.Y*width + p.X
+				state.groupsBySquare[square] = append(state.groupsBySquare[square], id)
+			}
+		}
+
+		e.boards[i] = state
+
+		for y, row := range b.squares {
+			for x, s := range row {
+				e.index[s.value] = append(e.index[s.value], placement{i, y*width + x})
+			}
+		}
+	}
+
+	return e
+}
+
+// draw marks number on every board that hasn't won yet and returns the
+// positions of the boards that won because of it, in order
+func (e *engine) draw(number int) []int {
+	var winners []int
+
+	for _, p := range e.index[number] {
+		state := &e.boards[p.board]
+		if state.won || state.marked[p.square] {
+			continue
+		}
+
+		state.marked[p.square] = true
+
+		for _, id := range state.groupsBySquare[p.square] {
+			state.counts[id]++
+			if state.counts[id] == state.sizes[id] && !state.won {
+				state.won = true
+				winners = append(winners, p.board)
+			}
+		}
+	}
+
+	// placements are indexed board by board, but a board with the number in
+	// more than one square could otherwise come out of order
+	sort.Ints(winners)
+
+	return winners
+}
+
+// solved returns a copy of the board at position i with the squares that
+// have been marked so far marked
+func (e *engine) solved(i int) board {
+	b := e.game.boards[i]
+	state := &e.boards[i]
+
+	squares := make([][]square, len(b.squares))
+	for y, row := range b.squares {
+		squares[y] = make([]square, len(row))
+		for x, s := range row {
+			s.marked = state.marked[y*state.width+x]
+			squares[y][x] = s
+		}
+	}
+	b.squares = squares
+
+	return b
+}
+
+// Run plays every number in turn and returns the boards in the order that
+// they won. Boards that never win aren't included.
+func (g *game) Run() []solvedBoard {
+	var result []solvedBoard
+
+	e := newEngine(g)
+
+	for i, number := range g.numbers {
+		for _, winner := range e.draw(number) {
+			b := e.solved(winner)
+			result = append(result, solvedBoard{
+				board:           b,
+				draw:            i,
+				lastNumberDrawn: number,
+				finalScore:      b.sumOfUnmarkedSquares() * number,
+			})
+		}
+	}
+
+	return result
+}
+
+// WinningDraws returns, for each board, the index in the draw order of the
+// number that makes it win, or -1 if it never does
+func (g *game) WinningDraws() []int {
+	result := make([]int, len(g.boards))
+	for i := range result {
+		result[i] = -1
+	}
+
+	for _, b := range g.Run() {
+		result[b.index-1] = b.draw
+	}
+
+	return result
+}
+
+// RigFor returns an order in which to draw the game's numbers so that the
+// board at position i wins before any other. It picks the smallest winning
+// group on that board whose numbers don't also complete a group on another
+// board, and draws those numbers first, followed by the rest in their
+// original order.
+func (g *game) RigFor(i int) ([]int, error) {
+	if i < 0 || i >= len(g.boards) {
+		return nil, fmt.Errorf("there is no board %d", i+1)
+	}
+
+	drawn := map[int]bool{}
+	for _, number := range g.numbers {
+		drawn[number] = true
+	}
+
+	b := &g.boards[i]
+	e := newEngine(g)
+	target := &e.boards[i]
+
+	// try the smallest groups first, since they need the fewest draws
+	groups := make([][]int, len(target.sizes))
+	for square, ids := range target.groupsBySquare {
+		for _, id := range ids {
+			groups[id] = append(groups[id], b.squares[square/target.width][square%target.width].value)
+		}
+	}
+	sort.SliceStable(groups, func(x, y int) bool {
+		return len(groups[x]) < len(groups[y])
+	})
+
+	for _, group := range groups {
+		if numbers, ok := rigGroup(g, i, group, drawn); ok {
+			return numbers, nil
+		}
+	}
+
+	return nil, fmt.Errorf("board %d can't win first: any way it could would let another board win too", i+1)
+}
+
+// rigGroup returns the draw order that has board i win using the numbers in
+// group, if that works
+func rigGroup(g *game, i int, group []int, drawn map[int]bool) ([]int, bool) {
+	first := map[int]bool{}
+	for _, number := range group {
+		if !drawn[number] {
+			// this group can never be completed
+			return nil, false
+		}
+		first[number] = true
+	}
+
+	order := make([]int, 0, len(g.numbers))
+	for _, number := range g.numbers {
+		if first[number] {
+			order = append(order, number)
+		}
+	}
+
+	// play the group's numbers on a fresh engine, making sure that no other
+	// board wins before board i does, or on the same draw
+	e := newEngine(g)
+
+	for _, number := range order {
+		for _, winner := range e.draw(number) {
+			if winner != i {
+				return nil, false
+			}
+		}
+		if e.boards[i].won {
+			break
+		}
+	}
+
+	if !e.boards[i].won {
+		return nil, false
+	}
+
+	for _, number := range g.numbers {
+		if !first[number] {
+			order = append(order, number)
+		}
+	}
+
+	return order, true
+}
@@ -0,0 +1,181 @@
+package day04
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/matthinz/aoc-golang/geom"
+)
+
+// rules holds the rules that ParseRule knows by name. InARow is also
+// available, as "N-in-a-row".
+var rules = map[string]Rule{
+	"standard":  Standard,
+	"rows":      Rows,
+	"columns":   Columns,
+	"diagonals": Diagonals,
+	"corners":   Corners,
+	"full-card": FullCard,
+}
+
+// Rule decides how a board of the given size can win. It returns groups of
+// squares, and a board wins as soon as every square in any one group has been
+// marked.
+type Rule func(width, height int) [][]geom.Point
+
+// Standard is the rule from the puzzle: any complete row or column wins
+var Standard = AnyOf(Rows, Columns)
+
+// Rows wins with any complete row
+func Rows(width, height int) [][]geom.Point {
+	var groups [][]geom.Point
+	for y := 0; y < height; y++ {
+		groups = append(groups, run(geom.Point{X: 0, Y: y}, geom.Point{X: 1, Y: 0}, width))
+	}
+	return groups
+}
+
+// Columns wins with any complete column
+func Columns(width, height int) [][]geom.Point {
+	var groups [][]geom.Point
+	for x := 0; x < width; x++ {
+		groups = append(groups, run(geom.Point{X: x, Y: 0}, geom.Point{X: 0, Y: 1}, height))
+	}
+	return groups
+}
+
+// Diagonals wins with either complete diagonal. Only square boards have
+// diagonals.
+func Diagonals(width, height int) [][]geom.Point {
+	if width != height || width == 0 {
+		return nil
+	}
+	return [][]geom.Point{
+		run(geom.Point{X: 0, Y: 0}, geom.Point{X: 1, Y: 1}, width),
+		run(geom.Point{X: width - 1, Y: 0}, geom.Point{X: -1, Y: 1}, width),
+	}
+}
+
+// Corners wins once all four corners are marked
+func Corners(width, height int) [][]geom.Point {
+	if width == 0 || height == 0 {
+		return nil
+	}
+
+	var corners []geom.Point
+	seen := map[geom.Point]bool{}
+
+	// on boards only one square wide or tall, corners are shared
+	for _, p := range []geom.Point{
+		{X: 0, Y: 0},
+		{X: width - 1, Y: 0},
+		{X: 0, Y: height - 1},
+		{X: width - 1, Y: height - 1},
+	} {
+		if !seen[p] {
+			seen[p] = true
+			corners = append(corners, p)
+		}
+	}
+
+	return [][]geom.Point{corners}
+}
+
+// FullCard wins once every square is marked
+func FullCard(width, height int) [][]geom.Point {
+	if width == 0 || height == 0 {
+		return nil
+	}
+
+	var all []geom.Point
+	for y := 0; y < height; y++ {
+		all = append(all, run(geom.Point{X: 0, Y: y}, geom.Point{X: 1, Y: 0}, width)...)
+	}
+	return [][]geom.Point{all}
+}
+
+// InARow returns a rule that wins with n marked squares in a line,
+// horizontally, vertically or diagonally
+func InARow(n int) Rule {
+	directions := []geom.Point{
+		{X: 1, Y: 0},
+		{X: 0, Y: 1},
+		{X: 1, Y: 1},
+		{X: -1, Y: 1},
+	}
+
+	return func(width, height int) [][]geom.Point {
+		if n <= 0 {
+			return nil
+		}
+
+		var groups [][]geom.Point
+
+		for y := 0; y < height; y++ {
+			for x := 0; x < width; x++ {
+				start := geom.Point{X: x, Y: y}
+				for _, d := range directions {
+					end := start.Add(d.Scale(n - 1))
+					if end.X < 0 || end.X >= width || end.Y >= height {
+						continue
+					}
+					groups = append(groups, run(start, d, n))
+				}
+			}
+		}
+
+		return groups
+	}
+}
+
+// AnyOf returns a rule that wins when any of rules would
+func AnyOf(rules ...Rule) Rule {
+	return func(width, height int) [][]geom.Point {
+		var groups [][]geom.Point
+		for _, r := range rules {
+			groups = append(groups, r(width, height)...)
+		}
+		return groups
+	}
+}
+
+// ParseRule returns the rule with the given name, e.g. "corners" or
+// "4-in-a-row". Names can be joined with "+" for a rule that wins when any of
+// them would, e.g. "standard+diagonals".
+func ParseRule(name string) (Rule, error) {
+	var parts []Rule
+
+	for _, part := range strings.Split(name, "+") {
+		part = strings.TrimSpace(part)
+
+		if r, found := rules[part]; found {
+			parts = append(parts, r)
+			continue
+		}
+
+		if count := strings.TrimSuffix(part, "-in-a-row"); count != part {
+			if n, err := strconv.Atoi(count); err == nil && n > 0 {
+				parts = append(parts, InARow(n))
+				continue
+			}
+		}
+
+		return nil, fmt.Errorf("unknown rule %q", part)
+	}
+
+	if len(parts) == 1 {
+		return parts[0], nil
+	}
+
+	return AnyOf(parts...), nil
+}
+
+// run returns the n points starting at start and stepping by step
+func run(start, step geom.Point, n int) []geom.Point {
+	result := make([]geom.Point, n)
+	for i := range result {
+		result[i] = start.Add(step.Scale(i))
+	}
+	return result
+}
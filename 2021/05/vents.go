@@ -2,190 +2,292 @@ package d05
 
 import (
 	"bufio"
+	"context"
 	_ "embed"
 	"fmt"
 	"io"
-	"log"
 	"regexp"
+	"sort"
 	"strconv"
-	"sync"
 
 	"github.com/matthinz/aoc-golang"
+	"github.com/matthinz/aoc-golang/geom"
+	"github.com/matthinz/aoc-golang/visual"
 )
 
-type point struct {
-	x int
-	y int
+// ventFrames is about how many frames to record while drawing the vents
+const ventFrames = 50
+
+// maxVentFrameSize is the most cells across or down a frame of vents can be
+// before it is scaled down
+const maxVentFrameSize = 1000
+
+// palette draws empty sea floor (index 0), a single vent (index 1) and places
+// where vents overlap (index 2)
+var palette = visual.Palette{
+	{Rune: '.', Color: visual.RGB(0, 20, 40)},
+	{Rune: '1', Color: visual.RGB(60, 140, 200)},
+	{Rune: '2', Color: visual.RGB(250, 90, 60)},
 }
 
 type line struct {
-	start point
-	end   point
+	start geom.Point
+	end   geom.Point
 }
 
 //go:embed input
 var defaultInput string
 
+//go:embed answers.json
+var answers string
+
+func init() {
+	aoc.Register(2021, New())
+}
+
 func New() aoc.Day {
-	return aoc.NewDay(5, defaultInput, Puzzle1, Puzzle2)
+	return aoc.NewDay(5, defaultInput, Puzzle1, Puzzle2).WithAnswers(answers)
 }
 
-func Puzzle1(r io.Reader, l *log.Logger) string {
+func Puzzle1(ctx context.Context, r io.Reader, l *aoc.Logger) (string, error) {
 	lines := ParseInput(r)
 
-	intersections := CalculateIntersections(lines, false)
+	if rec := visual.RecorderFrom(ctx); rec != nil {
+		recordVents(rec, lines, false)
+	}
 
-	var atLeast2 int
+	overlaps := Overlaps(lines, false)
 
-	for _, row := range intersections {
-		for _, ct := range row {
-			if ct >= 2 {
-				atLeast2++
-			}
-		}
-	}
+	l.Debugf("%d lines overlap at %d points", len(lines), len(overlaps))
 
-	return strconv.Itoa(atLeast2)
+	return strconv.Itoa(len(overlaps)), nil
 }
 
-func Puzzle2(r io.Reader, l *log.Logger) string {
+func Puzzle2(ctx context.Context, r io.Reader, l *aoc.Logger) (string, error) {
 	lines := ParseInput(r)
 
-	intersections := CalculateIntersections(lines, true)
-
-	var atLeast2 int
-
-	for _, row := range intersections {
-		for _, ct := range row {
-			if ct >= 2 {
-				atLeast2++
-			}
-		}
+	if rec := visual.RecorderFrom(ctx); rec != nil {
+		recordVents(rec, lines, true)
 	}
 
-	return strconv.Itoa(atLeast2)
-}
+	overlaps := Overlaps(lines, true)
 
-func (l *line) containsPoint(p point) bool {
+	l.Debugf("%d lines overlap at %d points", len(lines), len(overlaps))
 
-	var minX, minY, maxX, maxY int
+	return strconv.Itoa(len(overlaps)), nil
+}
 
-	if l.start.x < l.end.x {
-		minX = l.start.x
-		maxX = l.end.x
-	} else {
-		minX = l.end.x
-		maxX = l.start.x
+// containsPoint returns whether p is one of the points l passes through
+// (see points)
+func (l *line) containsPoint(p geom.Point) bool {
+	step, n := l.step()
+	d := p.Sub(l.start)
+
+	// p must be a whole number of steps along the line, and no more than n
+	var k int
+	switch {
+	case step.X != 0:
+		k = d.X / step.X
+	case step.Y != 0:
+		k = d.Y / step.Y
 	}
 
-	if l.start.y < l.end.y {
-		minY = l.start.y
-		maxY = l.end.y
-	} else {
-		minY = l.end.y
-		maxY = l.start.y
-	}
+	return k >= 0 && k <= n && step.Scale(k) == d
+}
 
-	xInBounds := p.x >= minX && p.x <= maxX
-	yInBounds := p.y >= minY && p.y <= maxY
+// points returns the lattice points that l passes through exactly, from start
+// to end. Horizontal, vertical and 45 degree lines pass through a point at
+// every step; lines at other slopes pass through fewer, e.g. 0,0 -> 4,2 only
+// through 0,0, 2,1 and 4,2.
+func (l *line) points() []geom.Point {
+	step, n := l.step()
 
-	if !(xInBounds && yInBounds) {
-		return false
+	result := make([]geom.Point, n+1)
+	for i := range result {
+		result[i] = l.start.Add(step.Scale(i))
 	}
 
-	var onTheLine bool
+	return result
+}
 
-	if l.isHorizontal() {
-		onTheLine = p.y == l.start.y
-	} else if l.isVertical() {
-		onTheLine = p.x == l.start.x
-	} else {
-		slope := (l.end.y - l.start.y) / (l.end.x - l.start.x)
-		yIntercept := l.start.y - (slope * l.start.x)
-		onTheLine = (p.y == slope*p.x+yIntercept)
-	}
+// step returns the smallest step from one lattice point on l to the next,
+// along with how many of those steps it takes to get from start to end
+func (l *line) step() (geom.Point, int) {
+	d := l.end.Sub(l.start)
 
-	if p.x == 1 && p.y == 9 && onTheLine {
-		fmt.Println(l)
+	n := gcd(abs(d.X), abs(d.Y))
+	if n == 0 {
+		return geom.Point{}, 0
 	}
 
-	return onTheLine
+	return geom.Point{X: d.X / n, Y: d.Y / n}, n
 }
 
 func (l *line) isHorizontal() bool {
-	return l.start.y == l.end.y
+	return l.start.Y == l.end.Y
 }
 
 func (l *line) isVertical() bool {
-	return l.start.x == l.end.x
+	return l.start.X == l.end.X
 }
 
-// Return a 2-dimensional array where each value is the number of intersections
-// at that poitn
-func CalculateIntersections(lines []line, includeDiagonals bool) [][]int {
-	var candidateLines []line
+// Coverage returns how many lines pass through each point that any line
+// passes through (see points). Only points on lines are stored, so far-apart
+// coordinates cost nothing extra.
+func Coverage(lines []line, includeDiagonals bool) map[geom.Point]int {
+	result := map[geom.Point]int{}
 
-	if includeDiagonals {
-		candidateLines = lines
-	} else {
-		for i := range lines {
-			if lines[i].isHorizontal() || lines[i].isVertical() {
-				candidateLines = append(candidateLines, lines[i])
-			}
+	for _, l := range candidates(lines, includeDiagonals) {
+		for _, p := range l.points() {
+			result[p]++
 		}
 	}
 
-	min, max := getMinMaxPoints(candidateLines)
+	return result
+}
 
-	width := max.x - min.x + 1
-	height := max.y - min.y + 1
+// Overlaps returns the lattice points that two or more lines pass through
+// (see points), ordered top to bottom and then left to right
+func Overlaps(lines []line, includeDiagonals bool) []geom.Point {
+	var result []geom.Point
 
-	result := make([][]int, height)
+	for p, count := range Coverage(lines, includeDiagonals) {
+		if count >= 2 {
+			result = append(result, p)
+		}
+	}
 
-	wg := sync.WaitGroup{}
-	wg.Add(height)
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Y != result[j].Y {
+			return result[i].Y < result[j].Y
+		}
+		return result[i].X < result[j].X
+	})
 
-	for y := 0; y < height; y++ {
+	return result
+}
+
+// Return a 2-dimensional array where each value is the number of intersections
+// at that point. The array covers the bounding box of the lines (and the
+// origin), so for large or far-apart coordinates Coverage or Overlaps are a
+// better fit.
+func CalculateIntersections(lines []line, includeDiagonals bool) [][]int {
+	min, max := getMinMaxPoints(candidates(lines, includeDiagonals))
+
+	width := max.X - min.X + 1
+	height := max.Y - min.Y + 1
+
+	result := make([][]int, height)
+	for y := range result {
 		result[y] = make([]int, width)
-		go func(y int) {
-			for x := 0; x < width; x++ {
-				p := point{x + min.x, y + min.y}
-				for i := range candidateLines {
-					if candidateLines[i].containsPoint(p) {
-						result[y][x]++
-					}
-				}
-			}
-			wg.Done()
-		}(y)
 	}
 
-	wg.Wait()
+	for p, count := range Coverage(lines, includeDiagonals) {
+		result[p.Y-min.Y][p.X-min.X] = count
+	}
 
 	return result
 }
 
-func getMinMaxPoints(lines []line) (point, point) {
-	var min, max point
+// candidates returns the lines that should be considered, which are only the
+// horizontal and vertical ones unless includeDiagonals is set
+func candidates(lines []line, includeDiagonals bool) []line {
+	if includeDiagonals {
+		return lines
+	}
+
+	var result []line
 	for i := range lines {
-		for _, p := range []point{lines[i].start, lines[i].end} {
-			if p.x < min.x {
-				min.x = p.x
-			}
-			if p.y < min.y {
-				min.y = p.y
-			}
-			if p.x > max.x {
-				max.x = p.x
+		if lines[i].isHorizontal() || lines[i].isVertical() {
+			result = append(result, lines[i])
+		}
+	}
+	return result
+}
+
+// recordVents draws the vents one line at a time, recording a frame every so
+// often and once all of them have been drawn. Frames only cover the area the
+// lines are in, and areas too big to show one point per cell are scaled down
+// until they fit, with each cell showing the most lines through any point in
+// it.
+func recordVents(rec visual.Recorder, lines []line, includeDiagonals bool) {
+	candidateLines := candidates(lines, includeDiagonals)
+	if len(candidateLines) == 0 {
+		return
+	}
+
+	min, max := extent(candidateLines)
+	size := max.Sub(min).Add(geom.Point{X: 1, Y: 1})
+
+	longest := size.X
+	if size.Y > longest {
+		longest = size.Y
+	}
+	scale := (longest + maxVentFrameSize - 1) / maxVentFrameSize
+
+	f := visual.NewFrame((size.X+scale-1)/scale, (size.Y+scale-1)/scale, palette)
+
+	coverage := map[geom.Point]int{}
+	every := (len(candidateLines) + ventFrames - 1) / ventFrames
+
+	for i, l := range candidateLines {
+		for _, p := range l.points() {
+			coverage[p]++
+
+			x, y := (p.X-min.X)/scale, (p.Y-min.Y)/scale
+			if count := coverage[p]; count > int(f.At(x, y)) && count <= 2 {
+				f.Set(x, y, uint8(count))
 			}
-			if p.y > max.y {
-				max.y = p.y
+		}
+
+		if (i+1)%every == 0 || i == len(candidateLines)-1 {
+			frame := f.Copy()
+			frame.Caption = fmt.Sprintf("%d of %d lines", i+1, len(candidateLines))
+			if scale > 1 {
+				frame.Caption += fmt.Sprintf(" (1:%d scale)", scale)
 			}
+			rec.Record(frame)
 		}
 	}
+}
+
+// extent returns the top left and bottom right corners of the smallest box
+// holding all of lines, which must not be empty
+func extent(lines []line) (geom.Point, geom.Point) {
+	min := lines[0].start
+	max := lines[0].start
+	for i := range lines {
+		min = min.Min(lines[i].start).Min(lines[i].end)
+		max = max.Max(lines[i].start).Max(lines[i].end)
+	}
 	return min, max
 }
 
+func getMinMaxPoints(lines []line) (geom.Point, geom.Point) {
+	var min, max geom.Point
+	for i := range lines {
+		min = min.Min(lines[i].start).Min(lines[i].end)
+		max = max.Max(lines[i].start).Max(lines[i].end)
+	}
+	return min, max
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// gcd returns the greatest common divisor of a and b, which must not be
+// negative. gcd(0, 0) is 0.
+func gcd(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
 func ParseInput(r io.Reader) []line {
 	scanner := bufio.NewScanner(r)
 
@@ -230,7 +332,7 @@ func parseLine(input string) *line {
 	}
 
 	return &line{
-		start: point{nums[0], nums[1]},
-		end:   point{nums[2], nums[3]},
+		start: geom.Point{X: nums[0], Y: nums[1]},
+		end:   geom.Point{X: nums[2], Y: nums[3]},
 	}
 }
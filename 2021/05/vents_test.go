@@ -5,6 +5,9 @@ import (
 	"strconv"
 	"strings"
 	"testing"
+
+	"github.com/matthinz/aoc-golang/geom"
+	"github.com/matthinz/aoc-golang/visual"
 )
 
 const INPUT = `
@@ -23,16 +26,16 @@ const INPUT = `
 func TestParseInput(t *testing.T) {
 
 	expected := []line{
-		line{point{0, 9}, point{5, 9}},
-		line{point{8, 0}, point{0, 8}},
-		line{point{9, 4}, point{3, 4}},
-		line{point{2, 2}, point{2, 1}},
-		line{point{7, 0}, point{7, 4}},
-		line{point{6, 4}, point{2, 0}},
-		line{point{0, 9}, point{2, 9}},
-		line{point{3, 4}, point{1, 4}},
-		line{point{0, 0}, point{8, 8}},
-		line{point{5, 5}, point{8, 2}},
+		line{geom.Point{X: 0, Y: 9}, geom.Point{X: 5, Y: 9}},
+		line{geom.Point{X: 8, Y: 0}, geom.Point{X: 0, Y: 8}},
+		line{geom.Point{X: 9, Y: 4}, geom.Point{X: 3, Y: 4}},
+		line{geom.Point{X: 2, Y: 2}, geom.Point{X: 2, Y: 1}},
+		line{geom.Point{X: 7, Y: 0}, geom.Point{X: 7, Y: 4}},
+		line{geom.Point{X: 6, Y: 4}, geom.Point{X: 2, Y: 0}},
+		line{geom.Point{X: 0, Y: 9}, geom.Point{X: 2, Y: 9}},
+		line{geom.Point{X: 3, Y: 4}, geom.Point{X: 1, Y: 4}},
+		line{geom.Point{X: 0, Y: 0}, geom.Point{X: 8, Y: 8}},
+		line{geom.Point{X: 5, Y: 5}, geom.Point{X: 8, Y: 2}},
 	}
 
 	lines := ParseInput(strings.NewReader(INPUT))
@@ -51,24 +54,43 @@ func TestParseInput(t *testing.T) {
 
 func TestContainsPoint(t *testing.T) {
 
-	l := line{start: point{0, 9}, end: point{5, 9}}
+	l := line{start: geom.Point{X: 0, Y: 9}, end: geom.Point{X: 5, Y: 9}}
 
-	if !l.containsPoint(point{1, 9}) {
+	if !l.containsPoint(geom.Point{X: 1, Y: 9}) {
 		t.Error("should've contained point")
 	}
 
-	if !l.containsPoint(point{5, 9}) {
+	if !l.containsPoint(geom.Point{X: 5, Y: 9}) {
 		t.Error("should've contained point")
 	}
 
-	if l.containsPoint(point{6, 9}) {
+	if l.containsPoint(geom.Point{X: 6, Y: 9}) {
 		t.Error("should not contain point")
 	}
 
-	if l.containsPoint(point{1, 1}) {
+	if l.containsPoint(geom.Point{X: 1, Y: 1}) {
 		t.Error("should not contain point")
 	}
 
+	sloped := line{start: geom.Point{X: 6, Y: 3}, end: geom.Point{X: 0, Y: 0}}
+
+	for _, p := range sloped.points() {
+		if !sloped.containsPoint(p) {
+			t.Errorf("%v should've contained %v", sloped, p)
+		}
+	}
+
+	for _, p := range []geom.Point{{X: 1, Y: 1}, {X: 3, Y: 2}, {X: 8, Y: 4}, {X: -2, Y: -1}} {
+		if sloped.containsPoint(p) {
+			t.Errorf("%v should not contain %v", sloped, p)
+		}
+	}
+
+	dot := line{start: geom.Point{X: 2, Y: 2}, end: geom.Point{X: 2, Y: 2}}
+	if !dot.containsPoint(geom.Point{X: 2, Y: 2}) || dot.containsPoint(geom.Point{X: 2, Y: 3}) {
+		t.Error("a single point line should only contain that point")
+	}
+
 }
 
 func TestCalculateIntersectionsNoDiagonals(t *testing.T) {
@@ -190,3 +212,166 @@ func formatIntersections(intersections [][]int) string {
 	}
 	return b.String()
 }
+
+func TestPoints(t *testing.T) {
+	tests := []struct {
+		l        line
+		expected []geom.Point
+	}{
+		{
+			line{geom.Point{X: 3, Y: 4}, geom.Point{X: 1, Y: 4}},
+			[]geom.Point{{X: 3, Y: 4}, {X: 2, Y: 4}, {X: 1, Y: 4}},
+		},
+		{
+			line{geom.Point{X: 7, Y: 0}, geom.Point{X: 7, Y: 2}},
+			[]geom.Point{{X: 7, Y: 0}, {X: 7, Y: 1}, {X: 7, Y: 2}},
+		},
+		{
+			line{geom.Point{X: 5, Y: 5}, geom.Point{X: 8, Y: 2}},
+			[]geom.Point{{X: 5, Y: 5}, {X: 6, Y: 4}, {X: 7, Y: 3}, {X: 8, Y: 2}},
+		},
+		{
+			line{geom.Point{X: 2, Y: 2}, geom.Point{X: 2, Y: 2}},
+			[]geom.Point{{X: 2, Y: 2}},
+		},
+		{
+			// a slope of 1/2 only passes through every other column
+			line{geom.Point{X: 0, Y: 0}, geom.Point{X: 4, Y: 2}},
+			[]geom.Point{{X: 0, Y: 0}, {X: 2, Y: 1}, {X: 4, Y: 2}},
+		},
+		{
+			// the same points, whichever end the line starts from
+			line{geom.Point{X: 4, Y: 2}, geom.Point{X: 0, Y: 0}},
+			[]geom.Point{{X: 4, Y: 2}, {X: 2, Y: 1}, {X: 0, Y: 0}},
+		},
+		{
+			// and a steep line with no lattice points between its ends has just
+			// those
+			line{geom.Point{X: 0, Y: 0}, geom.Point{X: -1, Y: 3}},
+			[]geom.Point{{X: 0, Y: 0}, {X: -1, Y: 3}},
+		},
+	}
+
+	for _, test := range tests {
+		actual := test.l.points()
+		if fmt.Sprint(actual) != fmt.Sprint(test.expected) {
+			t.Errorf("%v: expected %v, got %v", test.l, test.expected, actual)
+		}
+	}
+}
+
+func TestOverlaps(t *testing.T) {
+	lines := ParseInput(strings.NewReader(INPUT))
+
+	overlaps := Overlaps(lines, false)
+
+	expected := []geom.Point{
+		{X: 3, Y: 4}, {X: 7, Y: 4}, {X: 0, Y: 9}, {X: 1, Y: 9}, {X: 2, Y: 9},
+	}
+
+	if fmt.Sprint(overlaps) != fmt.Sprint(expected) {
+		t.Errorf("Expected %v, got %v", expected, overlaps)
+	}
+
+	if n := len(Overlaps(lines, true)); n != 12 {
+		t.Errorf("Expected 12 overlaps with diagonals, got %d", n)
+	}
+}
+
+func TestOverlapsOtherSlopes(t *testing.T) {
+	// parallel lines a unit apart never meet
+	parallel := []line{
+		{geom.Point{X: 0, Y: 0}, geom.Point{X: 4, Y: 2}},
+		{geom.Point{X: 4, Y: 3}, geom.Point{X: 0, Y: 1}},
+	}
+
+	if actual := Overlaps(parallel, true); len(actual) != 0 {
+		t.Errorf("Expected parallel lines not to overlap, got %v", actual)
+	}
+
+	// lines that cross at a lattice point overlap there, and lines that cross
+	// between lattice points don't overlap at all
+	crossing := []line{
+		{geom.Point{X: 0, Y: 0}, geom.Point{X: 4, Y: 2}},
+		{geom.Point{X: 0, Y: 2}, geom.Point{X: 4, Y: 0}},
+		{geom.Point{X: 5, Y: 3}, geom.Point{X: 6, Y: 0}},
+		{geom.Point{X: 5, Y: 0}, geom.Point{X: 6, Y: 3}},
+	}
+
+	expected := []geom.Point{{X: 2, Y: 1}}
+
+	if actual := Overlaps(crossing, true); fmt.Sprint(actual) != fmt.Sprint(expected) {
+		t.Errorf("Expected %v, got %v", expected, actual)
+	}
+}
+
+func TestOverlapsFarApart(t *testing.T) {
+	// far too big a bounding box to allocate
+	lines := []line{
+		{geom.Point{X: 0, Y: 0}, geom.Point{X: 4, Y: 0}},
+		{geom.Point{X: 2, Y: -2}, geom.Point{X: 2, Y: 2}},
+		{geom.Point{X: 1000000000, Y: 1000000000}, geom.Point{X: 1000000003, Y: 1000000000}},
+		{geom.Point{X: 1000000003, Y: 1000000003}, geom.Point{X: 1000000000, Y: 1000000000}},
+	}
+
+	expected := []geom.Point{
+		{X: 2, Y: 0},
+		{X: 1000000000, Y: 1000000000},
+	}
+
+	if actual := Overlaps(lines, false); fmt.Sprint(actual) != fmt.Sprint(expected[:1]) {
+		t.Errorf("Expected %v without diagonals, got %v", expected[:1], actual)
+	}
+
+	if actual := Overlaps(lines, true); fmt.Sprint(actual) != fmt.Sprint(expected) {
+		t.Errorf("Expected %v, got %v", expected, actual)
+	}
+}
+
+func TestRecordVents(t *testing.T) {
+	tests := []struct {
+		name          string
+		lines         []line
+		width, height int
+		overlap       geom.Point
+	}{
+		{
+			"cropped to the lines",
+			[]line{
+				{geom.Point{X: 100, Y: 50}, geom.Point{X: 103, Y: 50}},
+				{geom.Point{X: 101, Y: 49}, geom.Point{X: 101, Y: 52}},
+			},
+			4, 4,
+			geom.Point{X: 1, Y: 1},
+		},
+		{
+			"scaled down",
+			[]line{
+				{geom.Point{X: 0, Y: 0}, geom.Point{X: 0, Y: 2999}},
+				{geom.Point{X: 0, Y: 2500}, geom.Point{X: 4, Y: 2500}},
+			},
+			2, 1000,
+			geom.Point{X: 0, Y: 833},
+		},
+	}
+
+	for _, test := range tests {
+		var reel visual.Reel
+		recordVents(&reel, test.lines, false)
+
+		if len(reel.Frames) == 0 {
+			t.Errorf("%s: no frames recorded", test.name)
+			continue
+		}
+
+		f := reel.Frames[len(reel.Frames)-1]
+		if f.Width != test.width || f.Height != test.height {
+			t.Errorf("%s: expected a %dx%d frame, got %dx%d", test.name, test.width, test.height, f.Width, f.Height)
+			continue
+		}
+
+		if f.At(test.overlap.X, test.overlap.Y) != 2 {
+			t.Errorf("%s: expected an overlap at %v:\n%s", test.name, test.overlap, f)
+		}
+	}
+}
@@ -1,31 +1,45 @@
 package d06
 
 import (
-	"bufio"
+	"context"
 	_ "embed"
 	"io"
-	"log"
 	"strconv"
-	"strings"
 
 	"github.com/matthinz/aoc-golang"
+	"github.com/matthinz/aoc-golang/parse"
 )
 
 //go:embed input
 var defaultInput string
 
+//go:embed answers.json
+var answers string
+
+func init() {
+	aoc.Register(2021, New())
+}
+
 func New() aoc.Day {
-	return aoc.NewDay(6, defaultInput, Puzzle1, Puzzle2)
+	return aoc.NewDay(6, defaultInput, Puzzle1, Puzzle2).
+		WithAnswers(answers).
+		WithExample("3,4,3,1,2", "5934", "26984457539")
 }
 
-func Puzzle1(r io.Reader, l *log.Logger) string {
-	numbers := parseNumbers(r)
-	return strconv.Itoa(simulate(numbers, 80))
+func Puzzle1(ctx context.Context, r io.Reader, l *aoc.Logger) (string, error) {
+	numbers, err := parse.CommaInts(r)
+	if err != nil {
+		return "", err
+	}
+	return strconv.Itoa(simulate(numbers, 80)), nil
 }
 
-func Puzzle2(r io.Reader, l *log.Logger) string {
-	numbers := parseNumbers(r)
-	return strconv.Itoa(simulate(numbers, 256))
+func Puzzle2(ctx context.Context, r io.Reader, l *aoc.Logger) (string, error) {
+	numbers, err := parse.CommaInts(r)
+	if err != nil {
+		return "", err
+	}
+	return strconv.Itoa(simulate(numbers, 256)), nil
 }
 
 func simulate(numbers []int, days int) int {
@@ -67,27 +81,3 @@ func sum(values []int) int {
 	}
 	return result
 }
-
-func parseNumbers(r io.Reader) []int {
-
-	s := bufio.NewScanner(r)
-
-	for s.Scan() {
-		line := s.Text()
-		if len(line) == 0 {
-			continue
-		}
-
-		var result []int
-		for _, token := range strings.Split(line, ",") {
-			value, err := strconv.ParseInt(token, 10, 32)
-			if err != nil {
-				continue
-			}
-			result = append(result, int(value))
-		}
-		return result
-	}
-
-	return []int{}
-}
@@ -1,39 +1,53 @@
 package d07
 
 import (
-	"bufio"
+	"context"
 	_ "embed"
 	"io"
-	"log"
 	"math"
 	"sort"
 	"strconv"
-	"strings"
 
 	"github.com/matthinz/aoc-golang"
+	"github.com/matthinz/aoc-golang/parse"
 )
 
 //go:embed input
 var defaultInput string
 
+//go:embed answers.json
+var answers string
+
+func init() {
+	aoc.Register(2021, New())
+}
+
 func New() aoc.Day {
-	return aoc.NewDay(7, defaultInput, Puzzle1, Puzzle2)
+	return aoc.NewDay(7, defaultInput, Puzzle1, Puzzle2).
+		WithAnswers(answers).
+		WithExample("16,1,2,0,4,2,7,1,2,14", "37", "168")
 }
 
-func Puzzle1(r io.Reader, l *log.Logger) string {
-	positions := parseInput(r)
+func Puzzle1(ctx context.Context, r io.Reader, l *aoc.Logger) (string, error) {
+	positions, err := parse.CommaInts(r)
+	if err != nil {
+		return "", err
+	}
 
 	_, lowestCost := solve(positions, getNaiveCostToMoveToPosition)
 
-	return strconv.Itoa(lowestCost)
+	return strconv.Itoa(lowestCost), nil
 }
 
-func Puzzle2(r io.Reader, l *log.Logger) string {
-	positions := parseInput(r)
+func Puzzle2(ctx context.Context, r io.Reader, l *aoc.Logger) (string, error) {
+	positions, err := parse.CommaInts(r)
+	if err != nil {
+		return "", err
+	}
 
 	_, lowestCost := solve(positions, getCostToMoveToPosition)
 
-	return strconv.Itoa(lowestCost)
+	return strconv.Itoa(lowestCost), nil
 }
 
 func solve(positions []int, costFunc func(int, []int) int) (int, int) {
@@ -96,22 +110,3 @@ func costOfMove(from, to int) int {
 
 	return result
 }
-
-func parseInput(r io.Reader) []int {
-	var result []int
-
-	s := bufio.NewScanner(r)
-	for s.Scan() {
-		line := s.Text()
-		tokens := strings.Split(line, ",")
-		for _, token := range tokens {
-			value, err := strconv.ParseInt(token, 10, 32)
-			if err != nil {
-				continue
-			}
-			result = append(result, int(value))
-		}
-	}
-
-	return result
-}
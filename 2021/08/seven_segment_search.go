@@ -2,10 +2,10 @@ package d08
 
 import (
 	"bufio"
+	"context"
 	_ "embed"
 	"fmt"
 	"io"
-	"log"
 	"math"
 	"sort"
 	"strconv"
@@ -35,11 +35,18 @@ var SignalValuesForNumbers = [10]string{
 //go:embed input
 var defaultInput string
 
+//go:embed answers.json
+var answers string
+
+func init() {
+	aoc.Register(2021, New())
+}
+
 func New() aoc.Day {
-	return aoc.NewDay(8, defaultInput, Puzzle1, Puzzle2)
+	return aoc.NewDay(8, defaultInput, Puzzle1, Puzzle2).WithAnswers(answers)
 }
 
-func Puzzle1(r io.Reader, l *log.Logger) string {
+func Puzzle1(ctx context.Context, r io.Reader, l *aoc.Logger) (string, error) {
 	inputs := parseInput(r)
 	ch := make(chan []int)
 
@@ -68,10 +75,10 @@ func Puzzle1(r io.Reader, l *log.Logger) string {
 		}
 	}
 
-	return strconv.Itoa(result)
+	return strconv.Itoa(result), nil
 }
 
-func Puzzle2(r io.Reader, l *log.Logger) string {
+func Puzzle2(ctx context.Context, r io.Reader, l *aoc.Logger) (string, error) {
 	inputs := parseInput(r)
 	ch := make(chan int)
 
@@ -97,7 +104,7 @@ func Puzzle2(r io.Reader, l *log.Logger) string {
 		}
 	}
 
-	return strconv.Itoa(sum)
+	return strconv.Itoa(sum), nil
 }
 
 func makeIntFromDigits(digits []int) int {
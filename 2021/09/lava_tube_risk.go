@@ -1,189 +1,107 @@
 package d09
 
 import (
-	"bufio"
+	"context"
 	_ "embed"
+	"fmt"
 	"io"
-	"log"
 	"sort"
 	"strconv"
-	"strings"
 
 	"github.com/matthinz/aoc-golang"
+	"github.com/matthinz/aoc-golang/grid"
 )
 
-type point struct {
-	x      int
-	y      int
-	height int
-}
-
 //go:embed input
 var defaultInput string
 
-func New() aoc.Day {
-	return aoc.NewDay(9, defaultInput, Puzzle1, Puzzle2)
-}
-
-func Puzzle1(r io.Reader, l *log.Logger) string {
-	input := readInput(r)
-	lowPoints := getLowPoints(input)
+//go:embed answers.json
+var answers string
 
-	sumOfRiskLevels := 0
-	for _, p := range lowPoints {
-		sumOfRiskLevels += p.height + 1
-	}
+// exampleInput is the example from the puzzle description
+const exampleInput = `
+2199943210
+3987894921
+9856789892
+8767896789
+9899965678
+`
 
-	return strconv.Itoa(sumOfRiskLevels)
+func init() {
+	aoc.Register(2021, New())
 }
 
-func Puzzle2(r io.Reader, l *log.Logger) string {
-
-	input := readInput(r)
-	basins := findBasins(input)
-
-	sort.Slice(basins, func(i, j int) bool {
-		return len(basins[i]) > len(basins[j])
-	})
-
-	sizes := 1
-	for i, b := range basins[0:3] {
-		l.Printf("%d. %d\n", i+1, len(b))
-		sizes *= len(b)
-	}
-
-	return strconv.Itoa(sizes)
+func New() aoc.Day {
+	return aoc.NewDay(9, defaultInput, Puzzle1, Puzzle2).
+		WithAnswers(answers).
+		WithExample(exampleInput, "15", "1134")
 }
 
-func findBasins(input [][]int) [][]point {
-	var result [][]point
-
-	for _, p := range getLowPoints(input) {
-		basin := buildBasin(input, p, make([]point, 0))
-		result = append(result, basin)
+func Puzzle1(ctx context.Context, r io.Reader, l *aoc.Logger) (string, error) {
+	heights, err := grid.ParseDigits(r)
+	if err != nil {
+		return "", err
 	}
 
-	return result
-}
-
-func havePointsInCommon(basin1 []point, basin2 []point) bool {
-	for _, a := range basin1 {
-		if hasPoint(basin2, a.x, a.y) {
-			return true
-		}
+	sumOfRiskLevels := 0
+	for _, p := range getLowPoints(heights) {
+		sumOfRiskLevels += heights.Get(p) + 1
 	}
-	return false
-}
 
-func hasPoint(slice []point, x, y int) bool {
-	for _, p := range slice {
-		if p.x == x && p.y == y {
-			return true
-		}
-	}
-	return false
+	return strconv.Itoa(sumOfRiskLevels), nil
 }
 
-func buildBasin(input [][]int, p point, basin []point) []point {
+func Puzzle2(ctx context.Context, r io.Reader, l *aoc.Logger) (string, error) {
 
-	if p.height >= 9 {
-		return basin
+	heights, err := grid.ParseDigits(r)
+	if err != nil {
+		return "", err
 	}
 
-	for _, b := range basin {
-		if b.x == p.x && b.y == p.y {
-			// this basin already contains this point
-			return basin
-		}
-	}
+	basins := findBasins(heights)
 
-	result := append(basin, p)
-
-	// above
-	if p.y > 0 {
-		result = buildBasin(input, point{p.x, p.y - 1, input[p.y-1][p.x]}, result)
-	}
-
-	// below
-	if p.y < len(input)-1 {
-		result = buildBasin(input, point{p.x, p.y + 1, input[p.y+1][p.x]}, result)
+	if len(basins) < 3 {
+		return "", fmt.Errorf("expected at least 3 basins, found %d", len(basins))
 	}
 
-	// left
-	if p.x > 0 {
-		result = buildBasin(input, point{p.x - 1, p.y, input[p.y][p.x-1]}, result)
-	}
+	sort.Slice(basins, func(i, j int) bool {
+		return len(basins[i]) > len(basins[j])
+	})
 
-	// right
-	if p.x < len(input[p.y])-1 {
-		result = buildBasin(input, point{p.x + 1, p.y, input[p.y][p.x+1]}, result)
+	sizes := 1
+	for i, b := range basins[0:3] {
+		l.Debugf("%d. %d", i+1, len(b))
+		sizes *= len(b)
 	}
 
-	return result
+	return strconv.Itoa(sizes), nil
 }
 
-func getLowPoints(input [][]int) []point {
-	var result []point
-
-	for y, row := range input {
-		for x, height := range row {
-
-			adjacents := make([]int, 0, 4)
-
-			// above
-			if y > 0 {
-				adjacents = append(adjacents, input[y-1][x])
-			}
-
-			// below
-			if y < len(input)-1 {
-				adjacents = append(adjacents, input[y+1][x])
-			}
-
-			// left
-			if x > 0 {
-				adjacents = append(adjacents, input[y][x-1])
-			}
-
-			// right
-			if x < len(row)-1 {
-				adjacents = append(adjacents, input[y][x+1])
-			}
-
-			isLow := true
+func findBasins(heights *grid.Grid) [][]grid.Point {
+	var result [][]grid.Point
 
-			for _, adjacent := range adjacents {
-				if height >= adjacent {
-					isLow = false
-				}
-			}
-
-			if isLow {
-				result = append(result, point{x, y, height})
-			}
-		}
+	for _, p := range getLowPoints(heights) {
+		// Basins are bounded by points with a height of 9
+		basin := heights.FloodFill(p, grid.Orthogonal, func(p grid.Point, height int) bool {
+			return height < 9
+		})
+		result = append(result, basin)
 	}
 
 	return result
 }
 
-func readInput(r io.Reader) [][]int {
-	var result [][]int
-	var scanner = bufio.NewScanner(r)
+func getLowPoints(heights *grid.Grid) []grid.Point {
+	var result []grid.Point
 
-	for scanner.Scan() {
-		lineOfText := strings.TrimSpace(scanner.Text())
-		row := make([]int, 0, len(lineOfText))
-
-		for _, r := range lineOfText {
-			num, err := strconv.ParseInt(string(r), 10, 8)
-			if err != nil {
-				continue
+	heights.Each(func(p grid.Point, height int) {
+		for _, n := range heights.Neighbors4(p) {
+			if height >= heights.Get(n) {
+				return
 			}
-			row = append(row, int(num))
 		}
-		result = append(result, row)
-	}
+		result = append(result, p)
+	})
 
 	return result
 }
@@ -2,9 +2,10 @@ package d10
 
 import (
 	"bufio"
+	"context"
 	_ "embed"
+	"errors"
 	"io"
-	"log"
 	"sort"
 	"strconv"
 
@@ -51,11 +52,34 @@ type parsedLine struct {
 //go:embed input
 var defaultInput string
 
+//go:embed answers.json
+var answers string
+
+// exampleInput is the example from the puzzle description
+const exampleInput = `
+[({(<(())[]>[[{[]{<()<>>
+[(()[<>])]({[<{<<[]>>(
+{([(<{}[<>[]}>{[]{[(<()>
+(((({<>}<{<{<>}{[]{[]{}
+[[<[([]))<([[{}[[()]]]
+[{[{({}]{}}([{[{{{}}([]
+{<[[]]>}<{[{[{[]{()[[[]
+[<(<(<(<{}))><([]([]()
+<{([([[(<>()){}]>(<<{{
+<{([{{}}[<[[[<>{}]]]>[]]
+`
+
+func init() {
+	aoc.Register(2021, New())
+}
+
 func New() aoc.Day {
-	return aoc.NewDay(10, defaultInput, Puzzle1, Puzzle2)
+	return aoc.NewDay(10, defaultInput, Puzzle1, Puzzle2).
+		WithAnswers(answers).
+		WithExample(exampleInput, "26397", "288957")
 }
 
-func Puzzle1(r io.Reader, l *log.Logger) string {
+func Puzzle1(ctx context.Context, r io.Reader, l *aoc.Logger) (string, error) {
 	s := bufio.NewScanner(r)
 	var lineNumber int
 	var errorScore int
@@ -74,11 +98,11 @@ func Puzzle1(r io.Reader, l *log.Logger) string {
 		}
 	}
 
-	return strconv.Itoa(errorScore)
+	return strconv.Itoa(errorScore), nil
 
 }
 
-func Puzzle2(r io.Reader, l *log.Logger) string {
+func Puzzle2(ctx context.Context, r io.Reader, l *aoc.Logger) (string, error) {
 	s := bufio.NewScanner(r)
 	var lineNumber int
 	var errorScore int
@@ -101,13 +125,17 @@ func Puzzle2(r io.Reader, l *log.Logger) string {
 		}
 	}
 
+	if len(incompleteLines) == 0 {
+		return "", errors.New("no incomplete lines found")
+	}
+
 	sort.Slice(incompleteLines, func(i, j int) bool {
 		return incompleteLines[i].completionScore() < incompleteLines[j].completionScore()
 	})
 
 	midIndex := len(incompleteLines) / 2
 
-	return strconv.Itoa(incompleteLines[midIndex].completionScore())
+	return strconv.Itoa(incompleteLines[midIndex].completionScore()), nil
 }
 
 func (p *parsedLine) completionScore() int {
@@ -1,27 +1,60 @@
 package d11
 
 import (
-	"bufio"
+	"context"
 	_ "embed"
 	"fmt"
 	"io"
-	"log"
 	"strconv"
-	"strings"
 
 	"github.com/matthinz/aoc-golang"
+	"github.com/matthinz/aoc-golang/grid"
+	"github.com/matthinz/aoc-golang/visual"
 )
 
+// flashed marks an octopus that has already flashed during the current step,
+// so that it doesn't gain any more energy
+const flashed = -1
+
+// palette draws each octopus by its energy level, with those that have just
+// flashed (and so have an energy of 0) the brightest
+var palette = visual.Palette{
+	{Rune: '*', Color: visual.RGB(255, 255, 255)},
+	{Rune: '1', Color: visual.RGB(10, 20, 50)},
+	{Rune: '2', Color: visual.RGB(15, 30, 70)},
+	{Rune: '3', Color: visual.RGB(20, 40, 90)},
+	{Rune: '4', Color: visual.RGB(25, 50, 110)},
+	{Rune: '5', Color: visual.RGB(30, 60, 130)},
+	{Rune: '6', Color: visual.RGB(35, 70, 150)},
+	{Rune: '7', Color: visual.RGB(40, 80, 170)},
+	{Rune: '8', Color: visual.RGB(45, 90, 190)},
+	{Rune: '9', Color: visual.RGB(50, 100, 210)},
+}
+
 //go:embed input
 var defaultInput string
 
+//go:embed answers.json
+var answers string
+
+func init() {
+	aoc.Register(2021, New())
+}
+
 func New() aoc.Day {
-	return aoc.NewDay(11, defaultInput, Puzzle1, Puzzle2)
+	return aoc.NewDay(11, defaultInput, Puzzle1, Puzzle2).WithAnswers(answers)
 }
 
-func Puzzle1(r io.Reader, l *log.Logger) string {
+func Puzzle1(ctx context.Context, r io.Reader, l *aoc.Logger) (string, error) {
+
+	input, err := grid.ParseDigits(r)
+	if err != nil {
+		return "", err
+	}
+
+	rec := visual.RecorderFrom(ctx)
+	record(rec, input, 0)
 
-	input := parseInput(r)
 	totalFlashes := 0
 
 	for stepIndex := 0; stepIndex < 100; stepIndex++ {
@@ -30,66 +63,45 @@ func Puzzle1(r io.Reader, l *log.Logger) string {
 		totalFlashes += flashes
 
 		input = nextInput
+		record(rec, input, stepIndex+1)
 	}
 
-	return strconv.Itoa(totalFlashes)
+	return strconv.Itoa(totalFlashes), nil
 }
 
-func Puzzle2(r io.Reader, l *log.Logger) string {
-	input := parseInput(r)
+func Puzzle2(ctx context.Context, r io.Reader, l *aoc.Logger) (string, error) {
+	input, err := grid.ParseDigits(r)
+	if err != nil {
+		return "", err
+	}
+
+	area := input.Width() * input.Height()
 
-	height := len(input)
-	width := len(input[0])
-	area := width * height
+	rec := visual.RecorderFrom(ctx)
+	record(rec, input, 0)
 
 	stepIndex := 0
 
+	// an input whose octopuses never all flash at once would run forever
 	for {
+		if err := ctx.Err(); err != nil {
+			return "", err
+		}
+
 		stepIndex++
 
 		nextInput, flashes := step(input)
+		record(rec, nextInput, stepIndex)
 
 		if flashes == area {
-			return strconv.Itoa(stepIndex)
+			return strconv.Itoa(stepIndex), nil
 		}
 
 		input = nextInput
 	}
 }
 
-func parseInput(r io.Reader) [][]int {
-	var result [][]int
-	s := bufio.NewScanner(r)
-
-	width := 0
-
-	for s.Scan() {
-		line := strings.TrimSpace(s.Text())
-		if len(line) == 0 {
-			continue
-		}
-		if width == 0 {
-			width = len(line)
-		} else {
-			if len(line) != width {
-				panic("line is the wrong width")
-			}
-		}
-		row := make([]int, width)
-		for i, r := range line {
-			num, err := strconv.ParseInt(string(r), 10, 8)
-			if err != nil {
-				panic(err)
-			}
-			row[i] = int(num)
-		}
-		result = append(result, row)
-	}
-
-	return result
-}
-
-func step(input [][]int) ([][]int, int) {
+func step(input *grid.Grid) (*grid.Grid, int) {
 	result := increment(input)
 
 	totalFlashes := 0
@@ -105,84 +117,60 @@ func step(input [][]int) ([][]int, int) {
 	return reset(result), totalFlashes
 }
 
-func reset(input [][]int) [][]int {
-	height := len(input)
-	width := len(input[0])
+// record adds a frame showing the octopuses after the given step to rec, if
+// there is one
+func record(rec visual.Recorder, octopuses *grid.Grid, step int) {
+	if rec == nil {
+		return
+	}
 
-	for y := 0; y < height; y++ {
-		for x := 0; x < width; x++ {
-			if input[y][x] > 9 || input[y][x] == -1 {
-				input[y][x] = 0
-			}
+	f := visual.GridFrame(octopuses, palette, func(energy int) uint8 {
+		return uint8(energy)
+	})
+	f.Caption = fmt.Sprintf("step %d", step)
+
+	rec.Record(f)
+}
+
+func reset(input *grid.Grid) *grid.Grid {
+	input.Each(func(p grid.Point, energy int) {
+		if energy > 9 || energy == flashed {
+			input.Set(p, 0)
 		}
-	}
+	})
 
 	return input
 }
 
-func increment(input [][]int) [][]int {
-	for y := 0; y < len(input); y++ {
-		for x := 0; x < len(input[y]); x++ {
-			input[y][x]++
-		}
-	}
+func increment(input *grid.Grid) *grid.Grid {
+	input.Each(func(p grid.Point, energy int) {
+		input.Set(p, energy+1)
+	})
 	return input
 }
 
-func flash(input [][]int) ([][]int, int) {
+func flash(input *grid.Grid) (*grid.Grid, int) {
 
 	flashes := 0
 
-	height := len(input)
-	width := len(input[0])
-
-	// make a copy of the input for us to modify
-	result := make([][]int, height)
-	copy(result, input)
-
 	// flash everything in the input that is > 9
-	for y := 0; y < height; y++ {
-		for x := 0; x < width; x++ {
-			if input[y][x] <= 9 {
-				continue
-			}
-
-			for deltaY := -1; deltaY <= 1; deltaY++ {
-				for deltaX := -1; deltaX <= 1; deltaX++ {
-
-					yValid := y+deltaY >= 0 && y+deltaY < height
-					xValid := x+deltaX >= 0 && x+deltaX < width
-
-					if yValid && xValid {
-
-						hasAlreadyFlashed := result[y+deltaY][x+deltaX] == -1
-
-						if hasAlreadyFlashed {
-							// this octopus has already flashed on this tick
-							continue
-						}
-
-						result[y+deltaY][x+deltaX]++
-					}
+	input.Each(func(p grid.Point, energy int) {
+		if energy <= 9 {
+			return
+		}
 
-				}
+		for _, n := range input.Neighbors8(p) {
+			if input.Get(n) == flashed {
+				// this octopus has already flashed on this tick
+				continue
 			}
-
-			result[y][x] = -1 // sentinel value indicating "don't mess with this one again"
-
-			flashes++
+			input.Set(n, input.Get(n)+1)
 		}
-	}
 
-	return result, flashes
-}
+		input.Set(p, flashed)
 
-func printGrid(grid [][]int) {
-	for y := 0; y < len(grid); y++ {
-		fmt.Println()
-		for x := 0; x < len(grid[y]); x++ {
-			fmt.Print(grid[y][x])
-		}
-	}
-	fmt.Println()
+		flashes++
+	})
+
+	return input, flashes
 }
@@ -1,8 +1,14 @@
 package d11
 
 import (
+	"context"
 	"fmt"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/matthinz/aoc-golang"
+	"github.com/matthinz/aoc-golang/grid"
 )
 
 func BenchmarkStep(b *testing.B) {
@@ -48,7 +54,7 @@ func BenchmarkStep(b *testing.B) {
 
 	for n := 0; n < b.N; n++ {
 		for i := 0; i < len(steps)-1; i++ {
-			input := steps[i]
+			input := grid.FromRows(steps[i])
 			step(input)
 		}
 	}
@@ -96,13 +102,13 @@ func TestStep(t *testing.T) {
 	}
 
 	for i := 0; i < len(steps)-1; i++ {
-		input := steps[i]
+		input := grid.FromRows(steps[i])
 		expected := steps[i+1]
 
 		actual, _ := step(input)
 
 		t.Run(fmt.Sprintf("step %d", i), func(t *testing.T) {
-			assertEq(t, expected, actual)
+			assertEq(t, expected, actual.Rows())
 		})
 	}
 
@@ -138,10 +144,19 @@ func assertEq(t *testing.T, expected, actual [][]int) {
 	}
 
 	if !ok {
-		fmt.Println("EXPECTED")
-		printGrid(expected)
-		fmt.Println("ACTUAL")
-		printGrid(actual)
+		t.Logf("EXPECTED\n%s", grid.FromRows(expected))
+		t.Logf("ACTUAL\n%s", grid.FromRows(actual))
 	}
 
 }
+
+func TestPuzzle2NeverSynchronized(t *testing.T) {
+	// these two take turns flashing, so never flash together
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	answer, err := Puzzle2(ctx, strings.NewReader("09\n"), aoc.DiscardLogger())
+	if err != context.DeadlineExceeded {
+		t.Errorf("Expected %v, got %v (answer %q)", context.DeadlineExceeded, err, answer)
+	}
+}
@@ -2,10 +2,10 @@ package d12
 
 import (
 	"bufio"
+	"context"
 	_ "embed"
 	"fmt"
 	"io"
-	"log"
 	"strconv"
 	"strings"
 
@@ -22,16 +22,36 @@ type connection [2]cave
 //go:embed input
 var defaultInput string
 
+//go:embed answers.json
+var answers string
+
+// exampleInput is the example from the puzzle description
+const exampleInput = `
+start-A
+start-b
+A-c
+A-b
+b-d
+A-end
+b-end
+`
+
+func init() {
+	aoc.Register(2021, New())
+}
+
 func New() aoc.Day {
-	return aoc.NewDay(12, defaultInput, Puzzle1, Puzzle2)
+	return aoc.NewDay(12, defaultInput, Puzzle1, Puzzle2).
+		WithAnswers(answers).
+		WithExample(exampleInput, "10", "36")
 }
 
-func Puzzle1(r io.Reader, l *log.Logger) string {
+func Puzzle1(ctx context.Context, r io.Reader, l *aoc.Logger) (string, error) {
 
 	connections := parseInput(r)
 
 	for _, c := range connections {
-		l.Printf("%s <-> %s\n", c[0].String(), c[1].String())
+		l.Debugf("%s <-> %s", c[0].String(), c[1].String())
 	}
 
 	initialPath := []cave{
@@ -43,15 +63,15 @@ func Puzzle1(r io.Reader, l *log.Logger) string {
 
 	paths := buildPaths(connections, initialPath, 1)
 
-	return strconv.Itoa(len(paths))
+	return strconv.Itoa(len(paths)), nil
 
 }
 
-func Puzzle2(r io.Reader, l *log.Logger) string {
+func Puzzle2(ctx context.Context, r io.Reader, l *aoc.Logger) (string, error) {
 	connections := parseInput(r)
 
 	for _, c := range connections {
-		l.Printf("%s <-> %s\n", c[0].String(), c[1].String())
+		l.Debugf("%s <-> %s", c[0].String(), c[1].String())
 	}
 
 	initialPath := []cave{
@@ -63,7 +83,7 @@ func Puzzle2(r io.Reader, l *log.Logger) string {
 
 	paths := buildPaths(connections, initialPath, 2)
 
-	return strconv.Itoa(len(paths))
+	return strconv.Itoa(len(paths)), nil
 }
 
 func NewCave(name string) cave {
@@ -1,19 +1,60 @@
 package d13
 
 import (
+	"context"
 	_ "embed"
+	"errors"
+	"fmt"
 	"io"
-	"log"
 	"strconv"
 
 	"github.com/matthinz/aoc-golang"
+	"github.com/matthinz/aoc-golang/geom"
+	"github.com/matthinz/aoc-golang/visual"
 )
 
+// palette draws blank paper (index 0) and dots (index 1)
+var palette = visual.Palette{
+	{Rune: ' ', Color: visual.RGB(250, 245, 230)},
+	{Rune: 'X', Color: visual.RGB(30, 30, 30)},
+}
+
 //go:embed input
 var defaultInput string
 
-func Puzzle1(r io.Reader, l *log.Logger) string {
+//go:embed answers.json
+var answers string
+
+// exampleInput is the example from the puzzle description
+const exampleInput = `
+6,10
+0,14
+9,10
+0,3
+10,4
+4,11
+6,0
+6,12
+4,1
+0,13
+10,12
+3,4
+3,0
+8,4
+1,10
+2,14
+8,10
+9,0
+
+fold along y=7
+fold along x=5
+`
+
+func Puzzle1(ctx context.Context, r io.Reader, l *aoc.Logger) (string, error) {
 	sheet := parseInput(r)
+	rec := visual.RecorderFrom(ctx)
+	record(rec, &sheet, "unfolded")
+
 	for len(sheet.instructions) > 0 {
 		i := sheet.instructions[0]
 		nextSheet := sheet.fold()
@@ -26,15 +67,19 @@ func Puzzle1(r io.Reader, l *log.Logger) string {
 		}
 
 		l.Printf("fold along %s=%d leaves %d points\n", axis, value, len(nextSheet.dots))
+		record(rec, &nextSheet, fmt.Sprintf("fold along %s=%d", axis, value))
 
-		return strconv.Itoa(len(nextSheet.dots))
+		return strconv.Itoa(len(nextSheet.dots)), nil
 	}
 
-	return ""
+	return "", errors.New("no fold instructions found")
 }
 
-func Puzzle2(r io.Reader, l *log.Logger) string {
+func Puzzle2(ctx context.Context, r io.Reader, l *aoc.Logger) (string, error) {
 	sheet := parseInput(r)
+	rec := visual.RecorderFrom(ctx)
+	record(rec, &sheet, "unfolded")
+
 	for len(sheet.instructions) > 0 {
 		i := sheet.instructions[0]
 		nextSheet := sheet.fold()
@@ -47,12 +92,39 @@ func Puzzle2(r io.Reader, l *log.Logger) string {
 		}
 
 		l.Printf("fold along %s=%d leaves %d points\n", axis, value, len(nextSheet.dots))
+		record(rec, &nextSheet, fmt.Sprintf("fold along %s=%d", axis, value))
 		sheet = nextSheet
 	}
 
-	return sheet.String()
+	return sheet.String(), nil
+}
+
+// record adds a frame showing the dots on s to rec, if there is one
+func record(rec visual.Recorder, s *sheet, caption string) {
+	if rec == nil {
+		return
+	}
+
+	var max geom.Point
+	for _, d := range s.dots {
+		max = max.Max(d)
+	}
+
+	f := visual.NewFrame(max.X+1, max.Y+1, palette)
+	for _, d := range s.dots {
+		f.Set(d.X, d.Y, 1)
+	}
+	f.Caption = caption
+
+	rec.Record(f)
+}
+
+func init() {
+	aoc.Register(2021, New())
 }
 
 func New() aoc.Day {
-	return aoc.NewDay(13, defaultInput, Puzzle1, Puzzle2)
+	return aoc.NewDay(13, defaultInput, Puzzle1, Puzzle2).
+		WithAnswers(answers).
+		WithExample(exampleInput, "17", "XXXXX\nX   X\nX   X\nX   X\nXXXXX")
 }
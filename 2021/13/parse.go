@@ -6,6 +6,8 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+
+	"github.com/matthinz/aoc-golang/geom"
 )
 
 func parseInput(r io.Reader) sheet {
@@ -47,7 +49,7 @@ func parseInput(r io.Reader) sheet {
 	return result
 }
 
-func parsePoint(xStr, yStr string) point {
+func parsePoint(xStr, yStr string) geom.Point {
 	x, xErr := strconv.ParseInt(xStr, 10, 32)
 	if xErr != nil {
 		panic("Invalid X")
@@ -56,5 +58,5 @@ func parsePoint(xStr, yStr string) point {
 	if yErr != nil {
 		panic("Invalid Y")
 	}
-	return point{int(x), int(y)}
+	return geom.Point{X: int(x), Y: int(y)}
 }
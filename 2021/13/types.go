@@ -1,18 +1,17 @@
 package d13
 
-import "strings"
+import (
+	"strings"
 
-type point struct {
-	x int
-	y int
-}
+	"github.com/matthinz/aoc-golang/geom"
+)
 
 type foldInstruction struct {
 	x, y int
 }
 
 type sheet struct {
-	dots         []point
+	dots         []geom.Point
 	instructions []foldInstruction
 }
 
@@ -33,13 +32,13 @@ func (s *sheet) fold() sheet {
 
 		if instruction.x > 0 {
 			// fold is along the x axis, result will be narrower
-			if p.x > instruction.x {
-				foldedP.x = instruction.x - (p.x - instruction.x)
+			if p.X > instruction.x {
+				foldedP.X = instruction.x - (p.X - instruction.x)
 			}
 		} else if instruction.y > 0 {
 			// fold is along the y axis, result will be shorter
-			if p.y > instruction.y {
-				foldedP.y = instruction.y - (p.y - instruction.y)
+			if p.Y > instruction.y {
+				foldedP.Y = instruction.y - (p.Y - instruction.y)
 			}
 		}
 
@@ -62,18 +61,13 @@ func (s *sheet) fold() sheet {
 func (s *sheet) String() string {
 
 	// step 1 = make the final set of dots
-	var maxX, maxY int
+	var max geom.Point
 	for _, d := range s.dots {
-		if d.x > maxX {
-			maxX = d.x
-		}
-		if d.y > maxY {
-			maxY = d.y
-		}
+		max = max.Max(d)
 	}
 
-	width := maxX + 1
-	height := maxY + 1
+	width := max.X + 1
+	height := max.Y + 1
 
 	grid := make([][]rune, height)
 	for y := 0; y < height; y++ {
@@ -84,7 +78,7 @@ func (s *sheet) String() string {
 	}
 
 	for _, d := range s.dots {
-		grid[d.y][d.x] = 'X'
+		grid[d.Y][d.X] = 'X'
 	}
 
 	b := strings.Builder{}
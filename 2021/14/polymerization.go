@@ -1,15 +1,16 @@
 package d14
 
 import (
-	"bufio"
+	"context"
 	_ "embed"
+	"errors"
 	"io"
-	"log"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/matthinz/aoc-golang"
+	"github.com/matthinz/aoc-golang/parse"
 )
 
 type pair struct{ left, right byte }
@@ -28,25 +29,38 @@ type pairInsertionRule struct {
 //go:embed input
 var defaultInput string
 
+//go:embed answers.json
+var answers string
+
+func init() {
+	aoc.Register(2021, New())
+}
+
 func New() aoc.Day {
-	return aoc.NewDay(14, defaultInput, Puzzle1, Puzzle2)
+	return aoc.NewDay(14, defaultInput, Puzzle1, Puzzle2).WithAnswers(answers)
 }
 
-func Puzzle1(r io.Reader, l *log.Logger) string {
-	game := parseInput(r)
+func Puzzle1(ctx context.Context, r io.Reader, l *aoc.Logger) (string, error) {
+	game, err := parseInput(r)
+	if err != nil {
+		return "", err
+	}
 
 	m := run(game, 10)
 	mostCommonChar, leastCommonChar := findMostAndLeastCommon(m)
-	return strconv.Itoa(m[mostCommonChar] - m[leastCommonChar])
+	return strconv.Itoa(m[mostCommonChar] - m[leastCommonChar]), nil
 }
 
-func Puzzle2(r io.Reader, l *log.Logger) string {
-	game := parseInput(r)
+func Puzzle2(ctx context.Context, r io.Reader, l *aoc.Logger) (string, error) {
+	game, err := parseInput(r)
+	if err != nil {
+		return "", err
+	}
 
 	m := run(game, 40)
 	mostCommonChar, leastCommonChar := findMostAndLeastCommon(m)
 
-	return strconv.Itoa(m[mostCommonChar] - m[leastCommonChar])
+	return strconv.Itoa(m[mostCommonChar] - m[leastCommonChar]), nil
 }
 
 func run(g game, stepCount int) map[rune]int {
@@ -131,32 +145,49 @@ func findMostAndLeastCommon(characterCounts map[rune]int) (rune, rune) {
 	return mostCommon, leastCommon
 }
 
-func parseInput(r io.Reader) game {
-	b := bufio.NewScanner(r)
-	result := game{}
+// parseInput reads the polymer template, followed by a blank line and the
+// pair insertion rules, e.g.
+//
+//	NNCB
+//
+//	CH -> B
+//	HH -> N
+func parseInput(r io.Reader) (game, error) {
+	blocks, err := parse.Blocks(r)
+	if err != nil {
+		return game{}, err
+	}
+
+	if len(blocks) != 2 || len(blocks[0]) != 1 {
+		return game{}, errors.New("expected a template line, a blank line and then rules")
+	}
 
-	for b.Scan() {
-		l := strings.TrimSpace(b.Text())
+	template := blocks[0][0]
+	result := game{
+		initialPairs: parsePairs(strings.TrimSpace(template.Text)),
+	}
 
-		if len(l) == 0 {
-			continue
-		}
+	if len(result.initialPairs) == 0 {
+		return game{}, template.Errorf(-1, "template must have at least 2 elements")
+	}
 
-		if len(result.initialPairs) == 0 {
-			result.initialPairs = parsePairs(l)
-			continue
+	for _, line := range blocks[1] {
+		rule, err := line.KeyValue(" -> ")
+		if err != nil {
+			return game{}, err
 		}
 
-		parts := strings.Split(l, " -> ")
-		if len(parts) != 2 {
-			continue
+		if len(rule.Key) != 2 || len(rule.Value) != 1 {
+			return game{}, line.Errorf(-1, "expected a rule like \"CH -> B\", found %q", strings.TrimSpace(line.Text))
 		}
+
 		result.pairInsertionRules = append(
 			result.pairInsertionRules,
-			pairInsertionRule{parsePairs(parts[0])[0], parts[1][0]},
+			pairInsertionRule{parsePairs(rule.Key)[0], rule.Value[0]},
 		)
 	}
-	return result
+
+	return result, nil
 }
 
 func parsePairs(template string) []pair {
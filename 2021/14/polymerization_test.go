@@ -29,7 +29,10 @@ func TestStep(t *testing.T) {
 	CN -> C
 	`))
 
-	game := parseInput(input)
+	game, err := parseInput(input)
+	if err != nil {
+		t.Fatal(err)
+	}
 
 	result := run(game, 1)
 
@@ -62,7 +65,10 @@ func Test4Steps(t *testing.T) {
 	CN -> C
 	`))
 
-	game := parseInput(input)
+	game, err := parseInput(input)
+	if err != nil {
+		t.Fatal(err)
+	}
 
 	result := run(game, 4)
 
@@ -1,221 +1,104 @@
 package d15
 
 import (
-	"bufio"
+	"context"
 	_ "embed"
 	"io"
-	"log"
-	"math/rand"
-	"sort"
 	"strconv"
-	"strings"
-	"time"
 
 	"github.com/matthinz/aoc-golang"
+	"github.com/matthinz/aoc-golang/grid"
+	"github.com/matthinz/aoc-golang/search"
 )
 
-type point struct {
-	x         int
-	y         int
-	risk      int
-	totalRisk int
-}
-
-const unknownRisk = -1
-
 //go:embed input
 var defaultInput string
 
-func New() aoc.Day {
-	return aoc.NewDay(15, defaultInput, Puzzle1, Puzzle2)
-}
+//go:embed answers.json
+var answers string
 
-func Puzzle1(r io.Reader, l *log.Logger) string {
-	grid := parseInput(r)
-	lowestTotalRisk := solveDijkstra(grid, l)
-	return strconv.Itoa(lowestTotalRisk)
+func init() {
+	aoc.Register(2021, New())
 }
 
-func Puzzle2(r io.Reader, l *log.Logger) string {
-	grid := parseInput(r)
-	grid = inflateGrid(grid, 5)
-	lowestTotalRisk := solveDijkstra(grid, l)
-	return strconv.Itoa(lowestTotalRisk)
+func New() aoc.Day {
+	return aoc.NewDay(15, defaultInput, Puzzle1, Puzzle2).WithAnswers(answers)
 }
 
-func inflateGrid(grid *[][]int, inflationFactor int) *[][]int {
-	height := len(*grid)
-	width := len((*grid)[0])
-	inflatedGrid := make([][]int, height*inflationFactor)
-
-	for y := 0; y < height; y++ {
-		for i := 0; i < inflationFactor; i++ {
-			destY := (height * i) + y
-			inflatedGrid[destY] = make([]int, width*inflationFactor)
-
-			for x := 0; x < width; x++ {
-				for j := 0; j < inflationFactor; j++ {
-
-					destX := (width * j) + x
-
-					value := ((*grid)[y][x] + i + j)
-					if value > 9 {
-						value = value % 9
-					}
-
-					inflatedGrid[destY][destX] = value
-				}
-			}
-		}
+func Puzzle1(ctx context.Context, r io.Reader, l *aoc.Logger) (string, error) {
+	risks, err := grid.ParseDigits(r)
+	if err != nil {
+		return "", err
 	}
-
-	return &inflatedGrid
+	lowestTotalRisk, err := solve(ctx, risks, l)
+	if err != nil {
+		return "", err
+	}
+	return strconv.Itoa(lowestTotalRisk), nil
 }
 
-func solveDijkstra(grid *[][]int, l *log.Logger) int {
-
-	height := len(*grid)
-	width := len((*grid)[0])
-
-	// toVisit is a list of points, sorted by totalRisk -- from
-	// highest risk to lowest risk
-	toVisit := make([]point, 0, width*height)
-
-	// going in reverse here so that toVisit ends up with
-	// lowest totalRisk at the end
-	for y := height - 1; y >= 0; y-- {
-		for x := width - 1; x >= 0; x-- {
-			totalRisk := unknownRisk
-
-			if x == 0 && y == 0 {
-				// starting location = no risk
-				totalRisk = 0
-			}
-
-			p := point{
-				x:         x,
-				y:         y,
-				risk:      (*grid)[y][x],
-				totalRisk: totalRisk,
-			}
-
-			toVisit = append(toVisit, p)
-		}
+func Puzzle2(ctx context.Context, r io.Reader, l *aoc.Logger) (string, error) {
+	risks, err := grid.ParseDigits(r)
+	if err != nil {
+		return "", err
 	}
+	risks = inflateGrid(risks, 5)
+	lowestTotalRisk, err := solve(ctx, risks, l)
+	if err != nil {
+		return "", err
+	}
+	return strconv.Itoa(lowestTotalRisk), nil
+}
 
-	visited := make([]point, 0, width*height)
-
-	start := time.Now()
-
-	for len(toVisit) > 0 {
-
-		if rand.Float64() < 0.001 {
-			left := len(toVisit)
-			duration := time.Now().Sub(start)
-			processed := (width * height) - left
-			secondsToProcessOne := duration.Seconds() / float64(processed)
-			remainingSeconds := secondsToProcessOne * float64(left)
-
-			if left > 10000 {
-				l.Printf("%d to visit, %v elapsed, ~%fs remain\n", left, duration, remainingSeconds)
-			}
-		}
-
-		// Get the last point in toVisit, which will be the *least* risky
-		point, nextToVisit := popPoint(toVisit)
-		toVisit = nextToVisit
-
-		visited = append(visited, point)
-
-		needToSort := false
-
-		for i := range toVisit {
-
-			isNorthNeighbor := (toVisit[i].x == point.x && toVisit[i].y == point.y-1)
-			isSouthNeighbor := (toVisit[i].x == point.x && toVisit[i].y == point.y+1)
-			isEastNeighbor := (toVisit[i].x == point.x+1 && toVisit[i].y == point.y)
-			isWestNeighbor := (toVisit[i].x == point.x-1 && toVisit[i].y == point.y)
-
-			isNeighbor := isNorthNeighbor || isSouthNeighbor || isEastNeighbor || isWestNeighbor
-
-			if !isNeighbor {
-				continue
-			}
-
-			// we've found an unvisited neighbor compute its total risk, then update
-			// its position in <toVisit>
-			neighbor := &toVisit[i]
-			newRisk := point.totalRisk + neighbor.risk
-			if neighbor.totalRisk == unknownRisk || neighbor.totalRisk > newRisk {
-				neighbor.totalRisk = newRisk
-				needToSort = true
-			}
+// inflateGrid tiles risks inflationFactor times in each direction. Each tile
+// to the right or down adds 1 to the risk levels, wrapping from 9 back to 1.
+func inflateGrid(risks *grid.Grid, inflationFactor int) *grid.Grid {
+	return risks.Tile(inflationFactor, inflationFactor, func(risk, tileX, tileY int) int {
+		value := risk + tileX + tileY
+		if value > 9 {
+			value = value % 9
 		}
+		return value
+	})
+}
 
-		if !needToSort {
-			continue
+// solve returns the lowest total risk of any path from the top left of risks
+// to the bottom right. Entering a position costs its risk level.
+func solve(ctx context.Context, risks *grid.Grid, l *aoc.Logger) (int, error) {
+	start := grid.Point{X: 0, Y: 0}
+	end := grid.Point{X: risks.Width() - 1, Y: risks.Height() - 1}
+
+	// Every position is expanded at most once
+	progress := aoc.ProgressFrom(ctx)
+	progress.SetTotal(int64(risks.Width() * risks.Height()))
+
+	graph := search.GraphFunc(func(s search.State) []search.Edge {
+		progress.Add(1)
+		neighbors := risks.Neighbors4(s.(grid.Point))
+		edges := make([]search.Edge, len(neighbors))
+		for i, n := range neighbors {
+			edges[i] = search.Edge{To: n, Cost: risks.Get(n)}
 		}
+		return edges
+	})
 
-		// keep toVisit sorted such that the least risky points are at the end
-		sort.Slice(toVisit, func(i, j int) bool {
-
-			if toVisit[i].totalRisk == toVisit[j].totalRisk {
-				return false
-			}
-
-			// unknown risk = sort at the beginning
-			if toVisit[i].totalRisk == unknownRisk {
-				return true
-			}
-
-			if toVisit[j].totalRisk == unknownRisk {
-				return false
-			}
-
-			// known risk = the lower the number, the higher it is sorted
-			return toVisit[i].totalRisk > toVisit[j].totalRisk
-		})
+	isEnd := func(s search.State) bool {
+		return s == end
 	}
 
-	// visited now contains all points, each with the lowest possible risk
-	// to get to them
-	for _, p := range visited {
-		if p.x == width-1 && p.y == height-1 {
-			return p.totalRisk
-		}
+	// Every step costs at least 1, so the Manhattan distance to the end never
+	// overestimates the risk remaining
+	distanceToEnd := func(s search.State) int {
+		p := s.(grid.Point)
+		return (end.X - p.X) + (end.Y - p.Y)
 	}
 
-	panic("Could not determine total risk")
-}
-
-func popPoint(slice []point) (point, []point) {
-	sliceLen := len(slice)
-	p := slice[sliceLen-1]
-	newSlice := make([]point, sliceLen-1)
-	copy(newSlice, slice)
-	return p, newSlice
-}
-
-func parseInput(r io.Reader) *[][]int {
-	var grid [][]int
-	var width int
-
-	s := bufio.NewScanner(r)
-	for s.Scan() {
-		line := strings.TrimSpace(s.Text())
-		if len(line) == 0 {
-			continue
-		}
-		row := make([]int, 0, width)
-		for _, r := range line {
-			num, err := strconv.ParseInt(string(r), 10, 8)
-			if err != nil {
-				panic(err)
-			}
-			row = append(row, int(num))
-		}
-		grid = append(grid, row)
+	result, err := search.AStar(ctx, graph, start, isEnd, distanceToEnd)
+	if err != nil {
+		return 0, err
 	}
 
-	return &grid
+	l.Printf("expanded %d of %d positions", result.Expanded, risks.Width()*risks.Height())
+
+	return result.Cost, nil
 }
@@ -1,12 +1,15 @@
 package d15
 
 import (
-	"log"
+	"context"
 	"strings"
 	"testing"
+
+	"github.com/matthinz/aoc-golang"
+	"github.com/matthinz/aoc-golang/grid"
 )
 
-func TestSolveDijkstra(t *testing.T) {
+func TestSolve(t *testing.T) {
 	input := strings.NewReader(strings.TrimSpace(`
 1163751742
 1381373672
@@ -20,9 +23,15 @@ func TestSolveDijkstra(t *testing.T) {
 2311944581
 	`))
 
-	grid := parseInput(input)
+	risks, err := grid.ParseDigits(input)
+	if err != nil {
+		t.Fatal(err)
+	}
 
-	lowestTotalRisk := solveDijkstra(grid, log.Default())
+	lowestTotalRisk, err := solve(context.Background(), risks, aoc.DefaultLogger())
+	if err != nil {
+		t.Fatal(err)
+	}
 	if lowestTotalRisk != 40 {
 		t.Fatalf("Wrong answer -- expected %d, got %d", 40, lowestTotalRisk)
 	}
@@ -42,15 +51,18 @@ func TestInflateGrid(t *testing.T) {
 2311944581
 	`))
 
-	grid := parseInput(input)
+	risks, err := grid.ParseDigits(input)
+	if err != nil {
+		t.Fatal(err)
+	}
 
-	inflated := inflateGrid(grid, 5)
+	inflated := inflateGrid(risks, 5)
 
-	if (*inflated)[0][10] != 2 {
+	if inflated.Get(grid.Point{X: 10, Y: 0}) != 2 {
 		t.Fatalf("inflate failed")
 	}
 
-	if (*inflated)[49][49] != 9 {
+	if inflated.Get(grid.Point{X: 49, Y: 49}) != 9 {
 		t.Fatalf("inflate failed")
 	}
 
@@ -1,11 +1,10 @@
 package d16
 
 import (
+	"context"
 	_ "embed"
 	"fmt"
 	"io"
-	"log"
-	"os"
 	"strconv"
 	"strings"
 
@@ -18,7 +17,9 @@ type bitReader struct {
 	data      []byte
 	pos       int
 	bitOffset int
-	debug     bool
+
+	// if set, every read is logged at LevelDebug
+	l *aoc.Logger
 }
 
 type Packet struct {
@@ -33,40 +34,47 @@ const LiteralPacketTypeId = 4
 //go:embed input
 var defaultInput string
 
+//go:embed answers.json
+var answers string
+
+func init() {
+	aoc.Register(2021, New())
+}
+
 func New() aoc.Day {
-	return aoc.NewDay(16, defaultInput, Puzzle1, Puzzle2)
+	return aoc.NewDay(16, defaultInput, Puzzle1, Puzzle2).WithAnswers(answers)
 }
 
-func Puzzle1(r io.Reader, l *log.Logger) string {
+func Puzzle1(ctx context.Context, r io.Reader, l *aoc.Logger) (string, error) {
 
 	data := parseInput(r)
 
 	packet := parseRootPacket(data)
 
-	return strconv.Itoa(packet.sumVersions())
+	return strconv.Itoa(packet.sumVersions()), nil
 }
 
-func Puzzle2(r io.Reader, l *log.Logger) string {
+func Puzzle2(ctx context.Context, r io.Reader, l *aoc.Logger) (string, error) {
 
 	data := parseInput(r)
 
 	packet := parseRootPacket(data)
 
-	return strconv.FormatUint(packet.evaluate(), 10)
+	return strconv.FormatUint(packet.evaluate(), 10), nil
 }
 
-func printPacket(p *Packet, prefix string) {
+func logPacket(l *aoc.Logger, p *Packet, prefix string) {
 
-	fmt.Printf("%sv: %s (%d)\n", prefix, format4Bits(p.Version), p.Version)
-	fmt.Printf("%st: %s (%d)\n", prefix, format4Bits(p.TypeId), p.TypeId)
-	fmt.Printf("%svsum: %d\n", prefix, p.sumVersions())
+	l.Debugf("%sv: %s (%d)", prefix, format4Bits(p.Version), p.Version)
+	l.Debugf("%st: %s (%d)", prefix, format4Bits(p.TypeId), p.TypeId)
+	l.Debugf("%svsum: %d", prefix, p.sumVersions())
 
 	if p.TypeId == 4 {
-		fmt.Printf("%svalue: %d\n", prefix, p.LiteralValue)
+		l.Debugf("%svalue: %d", prefix, p.LiteralValue)
 	} else {
-		fmt.Printf("%ssp:\n", prefix)
+		l.Debugf("%ssp:", prefix)
 		for i := range p.Subpackets {
-			printPacket(&p.Subpackets[i], prefix+"  ")
+			logPacket(l, &p.Subpackets[i], prefix+"  ")
 		}
 	}
 
@@ -307,7 +315,7 @@ func parseOperatorPacket(version uint8, typeId uint8, r *bitReader) (Packet, int
 // bitReader implementation
 
 func newBitReader(data []byte) *bitReader {
-	return &bitReader{data, 0, 0, false}
+	return &bitReader{data, 0, 0, nil}
 }
 
 func (b *bitReader) atEnd() bool {
@@ -374,9 +382,9 @@ func (b *bitReader) read32(bits int) (uint32, error) {
 		}
 	}
 
-	if b.debug {
+	if b.l != nil && b.l.Enabled(aoc.LevelDebug) {
 		nice := format32Bits(result)
-		fmt.Fprintf(os.Stderr, "READ %s\n", nice[32-bits:32])
+		b.l.Debugf("read %s", nice[32-bits:32])
 	}
 
 	if b.pos >= len(b.data) {
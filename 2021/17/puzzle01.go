@@ -1,42 +1,79 @@
 package d17
 
 import (
+	"context"
 	_ "embed"
+	"fmt"
 	"io"
-	"log"
+	"regexp"
 	"strconv"
 
 	"github.com/matthinz/aoc-golang"
+	"github.com/matthinz/aoc-golang/parse"
 )
 
 const MaxSteps = 1000
-const MinInitialYVelocity = -10000
-const MaxInitialYVelocity = 100000
+
+// target is the area the probe needs to land in
+type target struct {
+	minX, maxX int
+	minY, maxY int
+}
+
+var targetPattern = regexp.MustCompile(`^target area: x=(-?\d+)\.\.(-?\d+), y=(-?\d+)\.\.(-?\d+)$`)
 
 //go:embed input
 var defaultInput string
 
-func New() aoc.Day {
-	return aoc.NewDay(17, defaultInput, Puzzle1, Puzzle2)
+//go:embed answers.json
+var answers string
+
+func init() {
+	aoc.Register(2021, New())
 }
 
-func Puzzle1(r io.Reader, l *log.Logger) string {
-	_, _, allTimeRecordY := solve(l)
-	return strconv.Itoa(allTimeRecordY)
+func New() aoc.Day {
+	return aoc.NewDay(17, defaultInput, Puzzle1, Puzzle2).
+		WithAnswers(answers).
+		WithExample("target area: x=20..30, y=-10..-5", "45", "112")
 }
 
-func Puzzle2(r io.Reader, l *log.Logger) string {
-	hits, _, _ := solve(l)
-	return strconv.Itoa(hits)
+func Puzzle1(ctx context.Context, r io.Reader, l *aoc.Logger) (string, error) {
+	t, err := parseInput(r)
+	if err != nil {
+		return "", err
+	}
+
+	_, _, allTimeRecordY, err := solve(ctx, t, l)
+	if err != nil {
+		return "", err
+	}
+	return strconv.Itoa(allTimeRecordY), nil
 }
 
-func solve(l *log.Logger) (int, int, int) {
+func Puzzle2(ctx context.Context, r io.Reader, l *aoc.Logger) (string, error) {
+	t, err := parseInput(r)
+	if err != nil {
+		return "", err
+	}
+
+	hits, _, _, err := solve(ctx, t, l)
+	if err != nil {
+		return "", err
+	}
+	return strconv.Itoa(hits), nil
+}
 
-	// target area: x=138..184, y=-125..-71
-	const minX = 138
-	const maxX = 184
-	const minY = -125
-	const maxY = -71
+// solve tries every initial velocity that could reach t, returning the
+// number of hits, the number of misses, and the highest y position reached on
+// the way to a hit. It gives up with ctx.Err() if ctx is done first.
+//
+// t is assumed to be below the launch point. Anything launched upward comes
+// back down through y=0 going one faster than it was launched, so launching
+// upward faster than -t.minY always overshoots.
+func solve(ctx context.Context, t target, l *aoc.Logger) (int, int, int, error) {
+	minX, maxX := t.minX, t.maxX
+	minY, maxY := t.minY, t.maxY
 
 	recordInitialXVelocity := 0
 	recordInitialYVelocity := 0
@@ -45,11 +82,18 @@ func solve(l *log.Logger) (int, int, int) {
 	hits := 0
 	misses := 0
 
-	minInitialXVelocity := -1 * maxX
-	maxInitialXVelocity := maxX + 1
+	// anything faster than this passes a target edge in a single step
+	minInitialXVelocity := min(minX, 0)
+	maxInitialXVelocity := max(maxX, 0) + 1
+	minInitialYVelocity := minY
+	maxInitialYVelocity := -minY + 1
 
 	for initialXVelocity := minInitialXVelocity; initialXVelocity < maxInitialXVelocity; initialXVelocity++ {
-		for initialYVelocity := MinInitialYVelocity; initialYVelocity < MaxInitialYVelocity; initialYVelocity++ {
+		if err := ctx.Err(); err != nil {
+			return hits, misses, allTimeRecordY, err
+		}
+
+		for initialYVelocity := minInitialYVelocity; initialYVelocity < maxInitialYVelocity; initialYVelocity++ {
 
 			x := 0
 			y := 0
@@ -72,7 +116,7 @@ func solve(l *log.Logger) (int, int, int) {
 						allTimeRecordY = localRecordY
 						recordInitialXVelocity = initialXVelocity
 						recordInitialYVelocity = initialYVelocity
-						l.Printf("New all-time record height of %d for velocity %d,%d!!!\n", allTimeRecordY, recordInitialXVelocity, recordInitialYVelocity)
+						l.Debugf("New all-time record height of %d for velocity %d,%d!!!", allTimeRecordY, recordInitialXVelocity, recordInitialYVelocity)
 					}
 					break
 				}
@@ -86,7 +130,7 @@ func solve(l *log.Logger) (int, int, int) {
 		}
 	}
 
-	return hits, misses, allTimeRecordY
+	return hits, misses, allTimeRecordY, nil
 }
 
 func doStep(x, y, xVelocity, yVelocity int) (int, int, int, int) {
@@ -110,3 +154,52 @@ func doStep(x, y, xVelocity, yVelocity int) (int, int, int, int) {
 
 	return newX, newY, xVelocity, yVelocity
 }
+
+// parseInput reads the target area, e.g.
+//
+//	target area: x=20..30, y=-10..-5
+func parseInput(r io.Reader) (target, error) {
+	records, err := parse.Records(r, targetPattern)
+	if err != nil {
+		return target{}, err
+	}
+
+	if len(records) != 1 {
+		return target{}, fmt.Errorf("expected 1 target area, found %d", len(records))
+	}
+
+	var values [4]int
+	for i := range values {
+		values[i], err = records[0].Int(i)
+		if err != nil {
+			return target{}, err
+		}
+	}
+
+	t := target{
+		minX: min(values[0], values[1]),
+		maxX: max(values[0], values[1]),
+		minY: min(values[2], values[3]),
+		maxY: max(values[2], values[3]),
+	}
+
+	if t.maxY >= 0 {
+		return target{}, records[0].Errorf(2, "target area must be below the launch point")
+	}
+
+	return t, nil
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -2,10 +2,10 @@ package d18
 
 import (
 	"bufio"
+	"context"
 	_ "embed"
 	"fmt"
 	"io"
-	"log"
 	"math"
 	"strconv"
 
@@ -30,11 +30,18 @@ type snailfishNumber struct {
 //go:embed input
 var defaultInput string
 
+//go:embed answers.json
+var answers string
+
+func init() {
+	aoc.Register(2021, New())
+}
+
 func New() aoc.Day {
-	return aoc.NewDay(18, defaultInput, Puzzle1, Puzzle2)
+	return aoc.NewDay(18, defaultInput, Puzzle1, Puzzle2).WithAnswers(answers)
 }
 
-func Puzzle1(r io.Reader, l *log.Logger) string {
+func Puzzle1(ctx context.Context, r io.Reader, l *aoc.Logger) (string, error) {
 
 	s := bufio.NewScanner(r)
 
@@ -50,7 +57,7 @@ func Puzzle1(r io.Reader, l *log.Logger) string {
 		parsed, err := parseSnailfishNumber(line)
 
 		if err != nil {
-			panic(err)
+			return "", err
 		}
 
 		numbers = append(numbers, *parsed)
@@ -62,11 +69,11 @@ func Puzzle1(r io.Reader, l *log.Logger) string {
 		}
 	}
 
-	return strconv.Itoa(num.magnitude())
+	return strconv.Itoa(num.magnitude()), nil
 
 }
 
-func Puzzle2(r io.Reader, l *log.Logger) string {
+func Puzzle2(ctx context.Context, r io.Reader, l *aoc.Logger) (string, error) {
 
 	s := bufio.NewScanner(r)
 
@@ -82,7 +89,7 @@ func Puzzle2(r io.Reader, l *log.Logger) string {
 		parsed, err := parseSnailfishNumber(line)
 
 		if err != nil {
-			panic(err)
+			return "", err
 		}
 
 		numbers = append(numbers, *parsed)
@@ -111,7 +118,7 @@ func Puzzle2(r io.Reader, l *log.Logger) string {
 		}
 	}
 
-	return strconv.Itoa(largestMagnitude)
+	return strconv.Itoa(largestMagnitude), nil
 
 }
 
@@ -307,13 +314,16 @@ func (s *snailfishNumber) checkDepths(expected int) {
 }
 
 func (s *snailfishNumber) reduce() {
-	s.reduceWithDebugging(false)
+	s.reduceWithLogging(nil)
 }
 
-func (s *snailfishNumber) reduceWithDebugging(debug bool) {
+// reduceWithLogging reduces s, logging each step to l unless it is nil
+func (s *snailfishNumber) reduceWithLogging(l *aoc.Logger) {
+
+	debug := l != nil
 
 	if debug {
-		fmt.Printf("reducing: %s\n", s.String())
+		l.Debugf("reducing: %s", s.String())
 	}
 
 	for {
@@ -342,7 +352,7 @@ func (s *snailfishNumber) reduceWithDebugging(debug bool) {
 
 				leftmostNested.explode()
 
-				fmt.Printf("reduce(): explode %s %s -> %s\n", value, before, s.String())
+				l.Debugf("reduce(): explode %s %s -> %s", value, before, s.String())
 			} else {
 				leftmostNested.explode()
 			}
@@ -359,7 +369,7 @@ func (s *snailfishNumber) reduceWithDebugging(debug bool) {
 				value := leftmost10OrGreater.value
 				before := s.String()
 				leftmost10OrGreater.split()
-				fmt.Printf("reduce(): split %d %s -> %s\n", value, before, s.String())
+				l.Debugf("reduce(): split %d %s -> %s", value, before, s.String())
 			} else {
 				leftmost10OrGreater.split()
 			}
@@ -367,7 +377,7 @@ func (s *snailfishNumber) reduceWithDebugging(debug bool) {
 		}
 
 		if debug {
-			fmt.Printf("reduce(): result %s\n", s.String())
+			l.Debugf("reduce(): result %s", s.String())
 		}
 
 		return
@@ -2,49 +2,33 @@ package d19
 
 import (
 	"bufio"
+	"context"
 	_ "embed"
 	"fmt"
 	"io"
-	"log"
-	"math"
 	"sort"
 	"strconv"
 	"strings"
 
 	"github.com/matthinz/aoc-golang"
-)
-
-type point struct {
-	x, y, z float64
-}
-
-type orientation string
-
-const (
-	UnknownOrientation = ""
-	XyzOrientation     = "xyz"
-	XzyOrientation     = "xzy"
-	YxzOrientation     = "yxz"
-	YzxOrientation     = "yzx"
-	ZxyOrientation     = "zxy"
-	ZyxOrientation     = "zyx"
+	"github.com/matthinz/aoc-golang/geom"
 )
 
 type scanner struct {
 	name    string
-	beacons []point
+	beacons []geom.Point3
 }
 
 type solution struct {
 	// the full set of unique beacons, relative to the global origin
-	beacons []point
+	beacons []geom.Point3
 	// each scanner, located in space, each with its beacons relative to that origin
 	scanners []solvedScanner
 }
 
 type solvedScanner struct {
 	scanner
-	location point
+	location geom.Point3
 }
 
 // minimum number of beacons two scanners must have in common for them to be considered "the same"
@@ -53,60 +37,56 @@ const MinBeaconsInCommon = 12
 // maximum visible distance for each scanner (a cube this many units on each side in either direction)
 const MaxScannerVisibility = 1000
 
-// degrees in which we increment our rotations about the x,y, and z axes
-const RotationIncrementDegrees = 90
-
 //go:embed input
 var defaultInput string
 
+//go:embed answers.json
+var answers string
+
+func init() {
+	aoc.Register(2021, New())
+}
+
 func New() aoc.Day {
-	return aoc.NewDay(19, defaultInput, Puzzle1, Puzzle2)
+	return aoc.NewDay(19, defaultInput, Puzzle1, Puzzle2).WithAnswers(answers)
 }
 
-func Puzzle1(r io.Reader, l *log.Logger) string {
+func Puzzle1(ctx context.Context, r io.Reader, l *aoc.Logger) (string, error) {
 	scanners := parseInput(r)
 
 	solution := solve(scanners)
 
-	return strconv.Itoa(len(solution.beacons))
+	return strconv.Itoa(len(solution.beacons)), nil
 }
 
-func Puzzle2(r io.Reader, l *log.Logger) string {
+func Puzzle2(ctx context.Context, r io.Reader, l *aoc.Logger) (string, error) {
 
 	scanners := parseInput(r)
 	solution := solve(scanners)
 
-	var maxDistance float64
+	var maxDistance int
 
 	for i := 0; i < len(solution.scanners); i++ {
 		for j := i + 1; j < len(solution.scanners); j++ {
-			distance := manhattanDistance(
-				solution.scanners[i].location,
-				solution.scanners[j].location,
-			)
+			distance := solution.scanners[i].location.ManhattanDistance(solution.scanners[j].location)
 			if distance > maxDistance {
 				maxDistance = distance
 			}
 		}
 	}
 
-	return strconv.FormatFloat(maxDistance, 'f', 0, 64)
+	return strconv.Itoa(maxDistance), nil
 }
 
 ////////////////////////////////////////////////////////////////////////////////
 
-func manhattanDistance(a, b point) float64 {
-	return math.Abs(a.x-b.x) + math.Abs(a.y-b.y) + math.Abs(a.z-b.z)
-
-}
-
 // given a set of scanners, returns a solution
 func solve(scanners []scanner) solution {
 	result := solution{
 		scanners: make([]solvedScanner, 0, len(scanners)),
 	}
 
-	uniqueBeacons := make(map[point]bool)
+	uniqueBeacons := make(map[geom.Point3]bool)
 
 	// Track original indices so we can sort result in the same way
 	scannerIndices := make(map[string]int)
@@ -124,7 +104,7 @@ func solve(scanners []scanner) solution {
 			// 0,0,0 in our space
 			result.scanners = append(result.scanners, solvedScanner{
 				scanner:  scanner,
-				location: point{0, 0, 0},
+				location: geom.Point3{},
 			})
 
 			for _, b := range scanner.beacons {
@@ -150,7 +130,7 @@ func solve(scanners []scanner) solution {
 			for _, b := range solved.beacons {
 				// b is relative to <solved>
 				// translate it into our global space
-				b = b.translate(solved.location)
+				b = b.Add(solved.location)
 				uniqueBeacons[b] = true
 			}
 
@@ -180,23 +160,7 @@ func solve(scanners []scanner) solution {
 	}
 
 	sort.Slice(result.beacons, func(i, j int) bool {
-		a := result.beacons[i]
-		b := result.beacons[j]
-
-		if a.x != b.x {
-			return a.x < b.x
-		}
-
-		if a.y != b.y {
-			return a.y < b.y
-		}
-
-		if a.z != b.z {
-			return a.z < b.z
-		}
-
-		return false
-
+		return result.beacons[i].Less(result.beacons[j])
 	})
 
 	sort.SliceStable(result.scanners, func(i, j int) bool {
@@ -211,10 +175,9 @@ func solveScanner(a scanner, b solvedScanner) (bool, *solvedScanner) {
 	var solution *solvedScanner
 	var bestBeaconsInCommon int
 
-	tryRotationsAndOrientations(func(rotation point, orientation orientation) bool {
+	tryRotations(func(rotation geom.Matrix) bool {
 
-		aBeacons := orientBeacons(a.beacons, orientation)
-		aBeacons = rotateBeacons(aBeacons, rotation)
+		aBeacons := rotateBeacons(a.beacons, rotation)
 
 		for _, aBeacon := range aBeacons {
 
@@ -224,7 +187,7 @@ func solveScanner(a scanner, b solvedScanner) (bool, *solvedScanner) {
 				// assumption. We translate all of <a>'s beacons into <b>'s space
 				// and search for overlap.
 
-				aBeaconsInBSpace := translateBeacons(aBeacons, aBeacon.inverse())
+				aBeaconsInBSpace := translateBeacons(aBeacons, aBeacon.Neg())
 				aBeaconsInBSpace = translateBeacons(aBeaconsInBSpace, bBeacon)
 
 				anyIllegalOnesFound := false
@@ -259,8 +222,7 @@ func solveScanner(a scanner, b solvedScanner) (bool, *solvedScanner) {
 					continue
 				}
 
-				aLocation := bBeacon.translate(aBeacon.inverse())
-				aLocation = aLocation.translate(b.location)
+				aLocation := bBeacon.Sub(aBeacon).Add(b.location)
 
 				// We have a potential solution
 				if solution == nil || beaconsInCommon > bestBeaconsInCommon {
@@ -286,154 +248,11 @@ func solveScanner(a scanner, b solvedScanner) (bool, *solvedScanner) {
 	return true, solution
 }
 
-////////////////////////////////////////////////////////////////////////////////
-// point methods
-
-func (p *point) applyMatrix(matrix [3][3]float64) point {
-	return point{
-		math.Round(matrix[0][0]*p.x + matrix[0][1]*p.y + matrix[0][2]*p.z),
-		math.Round(matrix[1][0]*p.x + matrix[1][1]*p.y + matrix[1][2]*p.z),
-		math.Round(matrix[2][0]*p.x + matrix[2][1]*p.y + matrix[2][2]*p.z),
-	}
-}
-
-// returns a new point with each coordinate inverted
-func (p *point) inverse() point {
-	return point{
-		x: -1 * p.x,
-		y: -1 * p.y,
-		z: -1 * p.z,
-	}
-}
-
-func (p *point) multiply(vector point) point {
-	return point{
-		x: p.x * vector.x,
-		y: p.y * vector.y,
-		z: p.z * vector.z,
-	}
-}
-
-func (p *point) orient(o orientation) point {
-	switch o {
-	case XyzOrientation:
-		return *p
-	case XzyOrientation:
-		return point{p.x, p.z, p.y}
-	case YxzOrientation:
-		return point{p.y, p.x, p.z}
-	case YzxOrientation:
-		return point{p.y, p.z, p.x}
-	case ZxyOrientation:
-		return point{p.z, p.x, p.y}
-	case ZyxOrientation:
-		return point{p.z, p.y, p.x}
-	default:
-		panic(fmt.Sprintf("Invalid orientation: %s", string(o)))
-	}
-}
-
-// rotates <p> using the given vector (in degrees)
-func (p *point) rotate(vector point) point {
-	result := p.rotateX(vector.x)
-	result = result.rotateY(vector.y)
-	result = result.rotateZ(vector.z)
-	return result
-}
-
-// rotates <p> <degrees> around the x axis
-func (p *point) rotateX(degrees float64) point {
-	radians := degrees * (math.Pi / 180)
-	matrix := [3][3]float64{
-		{1, 0, 0},
-		{0, math.Cos(radians), -1 * math.Sin(radians)},
-		{0, math.Sin(radians), math.Cos(radians)},
-	}
-	return p.applyMatrix(matrix)
-}
-
-// rotates <p> <degrees> around the y axis
-func (p *point) rotateY(degrees float64) point {
-	radians := degrees * (math.Pi / 180)
-	matrix := [3][3]float64{
-		{math.Cos(radians), 0, math.Sin(radians)},
-		{0, 1, 0},
-		{-1 * math.Sin(radians), 0, math.Cos(radians)},
-	}
-	return p.applyMatrix(matrix)
-}
-
-// rotates <p> <degrees> around the z axis
-func (p *point) rotateZ(degrees float64) point {
-	radians := degrees * (math.Pi / 180)
-	matrix := [3][3]float64{
-		{math.Cos(radians), -1 * math.Sin(radians), 0},
-		{math.Sin(radians), math.Cos(radians), 0},
-		{0, 0, 1},
-	}
-	return p.applyMatrix(matrix)
-
-}
-
-// returns a new point translated using the given vector
-func (p *point) translate(vector point) point {
-	return point{
-		x: p.x + vector.x,
-		y: p.y + vector.y,
-		z: p.z + vector.z,
-	}
-}
-
-// undoes the application of the given orientation to a point
-func (p *point) undoOrient(o orientation) point {
-	switch o {
-	case XyzOrientation:
-		return *p
-	case XzyOrientation:
-		return p.orient(XzyOrientation)
-	case YxzOrientation:
-		return p.orient(YxzOrientation)
-	case YzxOrientation:
-		return p.orient(ZxyOrientation)
-	case ZxyOrientation:
-		return p.orient(YzxOrientation)
-	case ZyxOrientation:
-		return p.orient(ZyxOrientation)
-	default:
-		panic(fmt.Sprintf("Unknown orientation: %s", o))
-	}
-}
-
-////////////////////////////////////////////////////////////////////////////////
-
-// calls <f> for a series of rotation / orientation options.
-func tryRotationsAndOrientations(f func(point, orientation) bool) {
-
-	rotationsToTry := make([]point, 0, int(math.Pow(360/RotationIncrementDegrees, 3)))
-
-	for x := 0.0; x < 360; x += RotationIncrementDegrees {
-		for y := 0.0; y < 360; y += RotationIncrementDegrees {
-			for z := 0.0; z < 360; z += RotationIncrementDegrees {
-				rotationsToTry = append(rotationsToTry, point{x, y, z})
-			}
-		}
-	}
-
-	orientationsToTry := []orientation{
-		XyzOrientation,
-		XzyOrientation,
-		YxzOrientation,
-		YzxOrientation,
-		ZxyOrientation,
-		ZyxOrientation,
-	}
-
-	for _, rotation := range rotationsToTry {
-		for _, orientation := range orientationsToTry {
-			success := f(rotation, orientation)
-			if success {
-				return
-			}
+// calls <f> with each of the 24 possible rotations until it returns true
+func tryRotations(f func(geom.Matrix) bool) {
+	for _, rotation := range geom.Rotations {
+		if f(rotation) {
+			return
 		}
 	}
 }
@@ -441,26 +260,16 @@ func tryRotationsAndOrientations(f func(point, orientation) bool) {
 ////////////////////////////////////////////////////////////////////////////////
 // point helpers
 
-func filterPoints(slice []point, f func(point) bool) []point {
-	result := make([]point, 0, len(slice))
-	for _, beacon := range slice {
-		if f(beacon) {
-			result = append(result, beacon)
-		}
-	}
-	return result
-}
-
-func mapPoints(slice []point, f func(point) point) []point {
-	result := make([]point, len(slice))
+func mapPoints(slice []geom.Point3, f func(geom.Point3) geom.Point3) []geom.Point3 {
+	result := make([]geom.Point3, len(slice))
 	for i := range slice {
 		result[i] = f(slice[i])
 	}
 	return result
 }
 
-func intersection(a, b []point) []point {
-	var result []point
+func intersection(a, b []geom.Point3) []geom.Point3 {
+	var result []geom.Point3
 
 	for _, aPoint := range a {
 		for _, bPoint := range b {
@@ -473,55 +282,23 @@ func intersection(a, b []point) []point {
 	return result
 }
 
-func beaconIsVisible(beacon point) bool {
-	return math.Abs(beacon.x) <= MaxScannerVisibility && math.Abs(beacon.y) <= MaxScannerVisibility && math.Abs(beacon.z) <= MaxScannerVisibility
+// scanners can see beacons within MaxScannerVisibility of them along each axis
+var visibleRange = geom.Box{
+	Min: geom.Point3{X: -MaxScannerVisibility, Y: -MaxScannerVisibility, Z: -MaxScannerVisibility},
+	Max: geom.Point3{X: MaxScannerVisibility, Y: MaxScannerVisibility, Z: MaxScannerVisibility},
 }
 
-// returns true if every point in <slice> is found in <in>
-func allBeaconsFound(slice []point, in []point) bool {
-
-	for _, p := range slice {
-		found := false
-		for _, o := range in {
-			if p == o {
-				found = true
-				break
-			}
-		}
-		if !found {
-			return false
-		}
-	}
-
-	return true
-}
-
-func orientBeacons(slice []point, o orientation) []point {
-	return mapPoints(slice, func(p point) point {
-		return p.orient(o)
-	})
+func beaconIsVisible(beacon geom.Point3) bool {
+	return visibleRange.Contains(beacon)
 }
 
-func unorientBeacons(slice []point, o orientation) []point {
-	return mapPoints(slice, func(p point) point {
-		return p.undoOrient(o)
-	})
-}
-
-func rotateBeacons(slice []point, rotation point) []point {
-	return mapPoints(slice, func(p point) point {
-		p = p.rotateX(rotation.x)
-		p = p.rotateY(rotation.y)
-		p = p.rotateZ(rotation.z)
-		return p
-	})
+func rotateBeacons(slice []geom.Point3, rotation geom.Matrix) []geom.Point3 {
+	return mapPoints(slice, rotation.Apply)
 }
 
 // returns a new slice in which each point in <slice> is translated by <translationVector>
-func translateBeacons(slice []point, translationVector point) []point {
-	return mapPoints(slice, func(p point) point {
-		return p.translate(translationVector)
-	})
+func translateBeacons(slice []geom.Point3, translationVector geom.Point3) []geom.Point3 {
+	return mapPoints(slice, translationVector.Add)
 }
 
 ////////////////////////////////////////////////////////////////////////////////
@@ -552,17 +329,17 @@ func parseInput(r io.Reader) []scanner {
 			continue
 		}
 
-		var nums []float64
+		var nums []int
 		for _, t := range tokens {
-			value, err := strconv.ParseFloat(t, 64)
+			value, err := strconv.Atoi(t)
 			if err != nil {
 				panic(err)
 			}
-			nums = append(nums, float64(value))
+			nums = append(nums, value)
 		}
 
 		scanner := &scanners[len(scanners)-1]
-		scanner.beacons = append(scanner.beacons, point{nums[0], nums[1], nums[2]})
+		scanner.beacons = append(scanner.beacons, geom.Point3{X: nums[0], Y: nums[1], Z: nums[2]})
 	}
 
 	return scanners
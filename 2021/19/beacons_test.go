@@ -3,6 +3,8 @@ package d19
 import (
 	"strings"
 	"testing"
+
+	"github.com/matthinz/aoc-golang/geom"
 )
 
 var TEST_INPUT = strings.TrimSpace(`
@@ -144,110 +146,6 @@ var TEST_INPUT = strings.TrimSpace(`
 	30,-46,-14
 	`)
 
-func TestOrient(t *testing.T) {
-	p := point{1, 2, 3}
-	tests := map[orientation]point{
-		XyzOrientation: point{1, 2, 3},
-		XzyOrientation: point{1, 3, 2},
-		YxzOrientation: point{2, 1, 3},
-		YzxOrientation: point{2, 3, 1},
-		ZxyOrientation: point{3, 1, 2},
-		ZyxOrientation: point{3, 2, 1},
-	}
-
-	for o, expected := range tests {
-		actual := p.orient(o)
-		if expected != actual {
-			t.Errorf("orient(%s) failed: expected %v, got %v", o, expected, actual)
-		}
-	}
-}
-
-func TestRotateX(t *testing.T) {
-
-	p := point{4, 3, 2}
-
-	expected := []point{
-		point{4, 3, 2},
-		point{4, -2, 3},
-		point{4, -3, -2},
-		point{4, 2, -3},
-	}
-
-	for i := 0; i < len(expected); i++ {
-		degrees := 90 * i
-		actual := p.rotateX(float64(degrees))
-		if actual != expected[i] {
-			t.Errorf("%d: expected %v, got %v", degrees, expected[i], actual)
-		}
-	}
-
-}
-
-func TestRotateY(t *testing.T) {
-
-	p := point{4, 3, 2}
-
-	expected := []point{
-		point{4, 3, 2},
-		point{2, 3, -4},
-		point{-4, 3, -2},
-		point{-2, 3, 4},
-	}
-
-	for i := 0; i < len(expected); i++ {
-		degrees := 90 * i
-		actual := p.rotateY(float64(degrees))
-		if actual != expected[i] {
-			t.Errorf("%d: expected %v, got %v", degrees, expected[i], actual)
-		}
-	}
-
-}
-
-func TestRotateZ(t *testing.T) {
-
-	p := point{4, 3, 2}
-
-	expected := []point{
-		point{4, 3, 2},
-		point{-3, 4, 2},
-		point{-4, -3, 2},
-		point{3, -4, 2},
-	}
-
-	for i := 0; i < len(expected); i++ {
-		degrees := 90 * i
-		actual := p.rotateZ(float64(degrees))
-		if actual != expected[i] {
-			t.Errorf("%d: expected %v, got %v", degrees, expected[i], actual)
-		}
-	}
-
-}
-
-func TestUndoOrient(t *testing.T) {
-	p := point{1, 2, 3}
-
-	orientations := []orientation{
-		XyzOrientation,
-		XzyOrientation,
-		YxzOrientation,
-		YzxOrientation,
-		ZxyOrientation,
-		ZyxOrientation,
-	}
-
-	for _, o := range orientations {
-		oriented := p.orient(o)
-		undone := oriented.undoOrient(o)
-		if undone != p {
-			t.Errorf("undoOrient(%v) failed. Expected %v, got %v", o, p, undone)
-		}
-	}
-
-}
-
 func TestSolveScanners0And1(t *testing.T) {
 	scanners := parseInput(strings.NewReader(TEST_INPUT))
 
@@ -272,7 +170,7 @@ func TestSolveScanners0And1(t *testing.T) {
 		t.Fatalf("Scanners in wrong order")
 	}
 
-	expectedLocation := point{68, -1246, -43}
+	expectedLocation := geom.Point3{X: 68, Y: -1246, Z: -43}
 	if scanner1.location != expectedLocation {
 		t.Errorf("Scanner 1 should be at %v, but was at %v", expectedLocation, scanner1.location)
 	}
@@ -290,24 +188,24 @@ func TestSolveScanners0And1And4(t *testing.T) {
 
 	solution := solve(scanners)
 
-	expectedLocation := point{-20, -1133, 1061}
+	expectedLocation := geom.Point3{X: -20, Y: -1133, Z: 1061}
 	if solution.scanners[2].location != expectedLocation {
 		t.Fatalf("Expected %s to be at %v, but was at %v", solution.scanners[2].name, expectedLocation, solution.scanners[2].location)
 	}
 
-	expectedScanner1And4Overlaps := []point{
-		point{459, -707, 401},
-		point{-739, -1745, 668},
-		point{-485, -357, 347},
-		point{432, -2009, 850},
-		point{528, -643, 409},
-		point{423, -701, 434},
-		point{-345, -311, 381},
-		point{408, -1815, 803},
-		point{534, -1912, 768},
-		point{-687, -1600, 576},
-		point{-447, -329, 318},
-		point{-635, -1737, 486},
+	expectedScanner1And4Overlaps := []geom.Point3{
+		{X: 459, Y: -707, Z: 401},
+		{X: -739, Y: -1745, Z: 668},
+		{X: -485, Y: -357, Z: 347},
+		{X: 432, Y: -2009, Z: 850},
+		{X: 528, Y: -643, Z: 409},
+		{X: 423, Y: -701, Z: 434},
+		{X: -345, Y: -311, Z: 381},
+		{X: 408, Y: -1815, Z: 803},
+		{X: 534, Y: -1912, Z: 768},
+		{X: -687, Y: -1600, Z: 576},
+		{X: -447, Y: -329, Z: 318},
+		{X: -635, Y: -1737, Z: 486},
 	}
 
 	scanner1BeaconsInGlobalSpace := translateBeacons(solution.scanners[1].beacons, solution.scanners[1].location)
@@ -367,12 +265,12 @@ func TestSolveAllScanners(t *testing.T) {
 		}
 	}
 
-	expectedLocations := []point{
-		point{0, 0, 0},
-		point{68, -1246, -43},
-		point{1105, -1205, 1229},
-		point{-92, -2380, -20},
-		point{-20, -1133, 1061},
+	expectedLocations := []geom.Point3{
+		{X: 0, Y: 0, Z: 0},
+		{X: 68, Y: -1246, Z: -43},
+		{X: 1105, Y: -1205, Z: 1229},
+		{X: -92, Y: -2380, Z: -20},
+		{X: -20, Y: -1133, Z: 1061},
 	}
 
 	for i, s := range solution.scanners {
@@ -381,86 +279,86 @@ func TestSolveAllScanners(t *testing.T) {
 		}
 	}
 
-	expectedBeacons := []point{
-		point{-892, 524, 684},
-		point{-876, 649, 763},
-		point{-838, 591, 734},
-		point{-789, 900, -551},
-		point{-739, -1745, 668},
-		point{-706, -3180, -659},
-		point{-697, -3072, -689},
-		point{-689, 845, -530},
-		point{-687, -1600, 576},
-		point{-661, -816, -575},
-		point{-654, -3158, -753},
-		point{-635, -1737, 486},
-		point{-631, -672, 1502},
-		point{-624, -1620, 1868},
-		point{-620, -3212, 371},
-		point{-618, -824, -621},
-		point{-612, -1695, 1788},
-		point{-601, -1648, -643},
-		point{-584, 868, -557},
-		point{-537, -823, -458},
-		point{-532, -1715, 1894},
-		point{-518, -1681, -600},
-		point{-499, -1607, -770},
-		point{-485, -357, 347},
-		point{-470, -3283, 303},
-		point{-456, -621, 1527},
-		point{-447, -329, 318},
-		point{-430, -3130, 366},
-		point{-413, -627, 1469},
-		point{-345, -311, 381},
-		point{-36, -1284, 1171},
-		point{-27, -1108, -65},
-		point{7, -33, -71},
-		point{12, -2351, -103},
-		point{26, -1119, 1091},
-		point{346, -2985, 342},
-		point{366, -3059, 397},
-		point{377, -2827, 367},
-		point{390, -675, -793},
-		point{396, -1931, -563},
-		point{404, -588, -901},
-		point{408, -1815, 803},
-		point{423, -701, 434},
-		point{432, -2009, 850},
-		point{443, 580, 662},
-		point{455, 729, 728},
-		point{456, -540, 1869},
-		point{459, -707, 401},
-		point{465, -695, 1988},
-		point{474, 580, 667},
-		point{496, -1584, 1900},
-		point{497, -1838, -617},
-		point{527, -524, 1933},
-		point{528, -643, 409},
-		point{534, -1912, 768},
-		point{544, -627, -890},
-		point{553, 345, -567},
-		point{564, 392, -477},
-		point{568, -2007, -577},
-		point{605, -1665, 1952},
-		point{612, -1593, 1893},
-		point{630, 319, -379},
-		point{686, -3108, -505},
-		point{776, -3184, -501},
-		point{846, -3110, -434},
-		point{1135, -1161, 1235},
-		point{1243, -1093, 1063},
-		point{1660, -552, 429},
-		point{1693, -557, 386},
-		point{1735, -437, 1738},
-		point{1749, -1800, 1813},
-		point{1772, -405, 1572},
-		point{1776, -675, 371},
-		point{1779, -442, 1789},
-		point{1780, -1548, 337},
-		point{1786, -1538, 337},
-		point{1847, -1591, 415},
-		point{1889, -1729, 1762},
-		point{1994, -1805, 1792},
+	expectedBeacons := []geom.Point3{
+		{X: -892, Y: 524, Z: 684},
+		{X: -876, Y: 649, Z: 763},
+		{X: -838, Y: 591, Z: 734},
+		{X: -789, Y: 900, Z: -551},
+		{X: -739, Y: -1745, Z: 668},
+		{X: -706, Y: -3180, Z: -659},
+		{X: -697, Y: -3072, Z: -689},
+		{X: -689, Y: 845, Z: -530},
+		{X: -687, Y: -1600, Z: 576},
+		{X: -661, Y: -816, Z: -575},
+		{X: -654, Y: -3158, Z: -753},
+		{X: -635, Y: -1737, Z: 486},
+		{X: -631, Y: -672, Z: 1502},
+		{X: -624, Y: -1620, Z: 1868},
+		{X: -620, Y: -3212, Z: 371},
+		{X: -618, Y: -824, Z: -621},
+		{X: -612, Y: -1695, Z: 1788},
+		{X: -601, Y: -1648, Z: -643},
+		{X: -584, Y: 868, Z: -557},
+		{X: -537, Y: -823, Z: -458},
+		{X: -532, Y: -1715, Z: 1894},
+		{X: -518, Y: -1681, Z: -600},
+		{X: -499, Y: -1607, Z: -770},
+		{X: -485, Y: -357, Z: 347},
+		{X: -470, Y: -3283, Z: 303},
+		{X: -456, Y: -621, Z: 1527},
+		{X: -447, Y: -329, Z: 318},
+		{X: -430, Y: -3130, Z: 366},
+		{X: -413, Y: -627, Z: 1469},
+		{X: -345, Y: -311, Z: 381},
+		{X: -36, Y: -1284, Z: 1171},
+		{X: -27, Y: -1108, Z: -65},
+		{X: 7, Y: -33, Z: -71},
+		{X: 12, Y: -2351, Z: -103},
+		{X: 26, Y: -1119, Z: 1091},
+		{X: 346, Y: -2985, Z: 342},
+		{X: 366, Y: -3059, Z: 397},
+		{X: 377, Y: -2827, Z: 367},
+		{X: 390, Y: -675, Z: -793},
+		{X: 396, Y: -1931, Z: -563},
+		{X: 404, Y: -588, Z: -901},
+		{X: 408, Y: -1815, Z: 803},
+		{X: 423, Y: -701, Z: 434},
+		{X: 432, Y: -2009, Z: 850},
+		{X: 443, Y: 580, Z: 662},
+		{X: 455, Y: 729, Z: 728},
+		{X: 456, Y: -540, Z: 1869},
+		{X: 459, Y: -707, Z: 401},
+		{X: 465, Y: -695, Z: 1988},
+		{X: 474, Y: 580, Z: 667},
+		{X: 496, Y: -1584, Z: 1900},
+		{X: 497, Y: -1838, Z: -617},
+		{X: 527, Y: -524, Z: 1933},
+		{X: 528, Y: -643, Z: 409},
+		{X: 534, Y: -1912, Z: 768},
+		{X: 544, Y: -627, Z: -890},
+		{X: 553, Y: 345, Z: -567},
+		{X: 564, Y: 392, Z: -477},
+		{X: 568, Y: -2007, Z: -577},
+		{X: 605, Y: -1665, Z: 1952},
+		{X: 612, Y: -1593, Z: 1893},
+		{X: 630, Y: 319, Z: -379},
+		{X: 686, Y: -3108, Z: -505},
+		{X: 776, Y: -3184, Z: -501},
+		{X: 846, Y: -3110, Z: -434},
+		{X: 1135, Y: -1161, Z: 1235},
+		{X: 1243, Y: -1093, Z: 1063},
+		{X: 1660, Y: -552, Z: 429},
+		{X: 1693, Y: -557, Z: 386},
+		{X: 1735, Y: -437, Z: 1738},
+		{X: 1749, Y: -1800, Z: 1813},
+		{X: 1772, Y: -405, Z: 1572},
+		{X: 1776, Y: -675, Z: 371},
+		{X: 1779, Y: -442, Z: 1789},
+		{X: 1780, Y: -1548, Z: 337},
+		{X: 1786, Y: -1538, Z: 337},
+		{X: 1847, Y: -1591, Z: 415},
+		{X: 1889, Y: -1729, Z: 1762},
+		{X: 1994, Y: -1805, Z: 1792},
 	}
 
 	for _, b := range solution.beacons {
@@ -472,7 +370,7 @@ func TestSolveAllScanners(t *testing.T) {
 			}
 		}
 		if !isExpected {
-			t.Errorf("UNEXPECTED BEACON: %d,%d,%d", int(b.x), int(b.y), int(b.z))
+			t.Errorf("UNEXPECTED BEACON: %v", b)
 		}
 	}
 
@@ -485,7 +383,7 @@ func TestSolveAllScanners(t *testing.T) {
 			}
 		}
 		if !wasFound {
-			t.Errorf("MISSING BEACON: %d,%d,%d", int(eb.x), int(eb.y), int(eb.z))
+			t.Errorf("MISSING BEACON: %v", eb)
 		}
 	}
 
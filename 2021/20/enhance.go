@@ -1,55 +1,91 @@
 package d20
 
 import (
-	"bufio"
+	"context"
 	_ "embed"
+	"errors"
 	"fmt"
 	"io"
-	"log"
 	"strconv"
 	"strings"
 
 	"github.com/matthinz/aoc-golang"
+	"github.com/matthinz/aoc-golang/grid"
+	"github.com/matthinz/aoc-golang/visual"
 )
 
+// Pixel values, as they appear in the input
+const (
+	lit  = '#'
+	dark = '.'
+)
+
+// palette draws dark pixels (index 0) and lit ones (index 1)
+var palette = visual.Palette{
+	{Rune: ' ', Color: visual.RGB(0, 0, 0)},
+	{Rune: '#', Color: visual.RGB(255, 255, 255)},
+}
+
 type image struct {
-	width         int
-	height        int
-	pixels        [][]bool
-	infinitePixel bool
+	pixels *grid.Grid
+
+	// value of every pixel outside of pixels, which stretch out to infinity
+	infinitePixel int
 }
 
 //go:embed input
 var defaultInput string
 
+//go:embed answers.json
+var answers string
+
+func init() {
+	aoc.Register(2021, New())
+}
+
 func New() aoc.Day {
-	return aoc.NewDay(20, defaultInput, Puzzle1, Puzzle2)
+	return aoc.NewDay(20, defaultInput, Puzzle1, Puzzle2).WithAnswers(answers)
 }
 
-func Puzzle1(r io.Reader, l *log.Logger) string {
-	img, algorithm := parseInput(r)
+func Puzzle1(ctx context.Context, r io.Reader, l *aoc.Logger) (string, error) {
+	img, algorithm, err := parseInput(r)
+	if err != nil {
+		return "", err
+	}
+
+	rec := visual.RecorderFrom(ctx)
+	record(rec, &img, 0)
 
 	enhanced := enhance(&img, algorithm)
+	record(rec, enhanced, 1)
 
 	enhanced = enhance(enhanced, algorithm)
+	record(rec, enhanced, 2)
 
 	count := countLitPixels(enhanced)
 
-	return strconv.Itoa(count)
+	return strconv.Itoa(count), nil
 }
 
-func Puzzle2(r io.Reader, l *log.Logger) string {
-	img, algorithm := parseInput(r)
+func Puzzle2(ctx context.Context, r io.Reader, l *aoc.Logger) (string, error) {
+	img, algorithm, err := parseInput(r)
+	if err != nil {
+		return "", err
+	}
+
+	rec := visual.RecorderFrom(ctx)
+	record(rec, &img, 0)
 
 	enhanced := &img
 
 	for i := 0; i < 50; i++ {
 		enhanced = enhance(enhanced, algorithm)
+		record(rec, enhanced, i+1)
 	}
 
 	count := countLitPixels(enhanced)
 
-	return strconv.Itoa(count)
+	return strconv.Itoa(count), nil
 }
 
 ////////////////////////////////////////////////////////////////////////////////
@@ -58,232 +94,133 @@ func Puzzle2(r io.Reader, l *log.Logger) string {
 func enhance(img *image, algorithm []bool) *image {
 
 	result := image{
-		width:  img.width + 2,
-		height: img.height + 2,
+		pixels: grid.New(img.pixels.Width()+2, img.pixels.Height()+2),
 	}
-	result.pixels = make([][]bool, result.height)
 
-	if img.infinitePixel {
+	if img.infinitePixel == lit {
 		// new infinite pixel will be algorithm at 0x111111111 (511)
-		result.infinitePixel = algorithm[511]
+		result.infinitePixel = pixelFor(algorithm[511])
 	} else {
 		// new infinite pixel will be algorithm at 0
-		result.infinitePixel = algorithm[0]
+		result.infinitePixel = pixelFor(algorithm[0])
 	}
 
-	for destY := 0; destY < result.height; destY++ {
-		srcY := destY - 1
-		result.pixels[destY] = make([]bool, result.width)
-
-		for destX := 0; destX < result.width; destX++ {
-			srcX := destX - 1
-			chunk := extractChunk(img, srcX, srcY)
+	result.pixels.Each(func(dest grid.Point, _ int) {
+		src := grid.Point{X: dest.X - 1, Y: dest.Y - 1}
+		chunk := extractChunk(img, src.X, src.Y)
 
-			index := chunkToIndex(chunk)
+		index := chunkToIndex(chunk)
 
-			result.pixels[destY][destX] = algorithm[index]
-		}
-	}
+		result.pixels.Set(dest, pixelFor(algorithm[index]))
+	})
 
 	return &result
 }
 
-func trimImage(img *image) *image {
-
-	leftMost := -1
-	topMost := -1
-	rightMost := -1
-	bottomMost := -1
-
-	for y := 0; y < img.height; y++ {
-		for x := 0; x < img.width; x++ {
-
-			pixel := img.pixels[y][x]
-			if !pixel {
-				continue
-			}
-
-			if leftMost == -1 || x < leftMost {
-				leftMost = x
-			}
-
-			if rightMost == -1 || x > rightMost {
-				rightMost = x
-			}
-
-			if topMost == -1 || y < topMost {
-				topMost = y
-			}
-
-			if bottomMost == -1 || y > bottomMost {
-				bottomMost = y
-			}
-		}
+// record adds a frame showing img after it has been enhanced the given number
+// of times to rec, if there is one
+func record(rec visual.Recorder, img *image, times int) {
+	if rec == nil {
+		return
 	}
 
-	newWidth := rightMost - leftMost + 1
-	newHeight := bottomMost - topMost + 1
-
-	if newWidth < 0 || newHeight < 0 {
-		return &image{
-			width:  0,
-			height: 0,
+	f := visual.GridFrame(img.pixels, palette, func(pixel int) uint8 {
+		if pixel == lit {
+			return 1
 		}
-	}
-
-	result := image{
-		width:  newWidth,
-		height: newHeight,
-	}
+		return 0
+	})
+	f.Caption = fmt.Sprintf("enhanced %d times", times)
 
-	result.pixels = make([][]bool, result.height)
-
-	for srcY := topMost; srcY <= bottomMost; srcY++ {
-		destY := srcY - topMost
-		result.pixels[destY] = make([]bool, result.width)
+	rec.Record(f)
+}
 
-		for srcX := leftMost; srcX <= rightMost; srcX++ {
-			destX := srcX - leftMost
-			result.pixels[destY][destX] = img.pixels[srcY][srcX]
-		}
+func pixelFor(on bool) int {
+	if on {
+		return lit
 	}
-
-	return &result
+	return dark
 }
 
-func chunkToIndex(chunk [][]bool) int {
+func chunkToIndex(chunk *grid.Grid) int {
 	// chunk is read left-to-right, top-to-bottom as a 9 bit binary number
 	var result int
 
-	for y := 0; y < 3; y++ {
-		for x := 0; x < 3; x++ {
-			result <<= 1
-			if chunk[y][x] {
-				result = result | 1
-			}
+	chunk.Each(func(p grid.Point, pixel int) {
+		result <<= 1
+		if pixel == lit {
+			result = result | 1
 		}
-	}
+	})
 
 	return result
 }
 
 // extracts a 3x3 matrix from <img> centered on x,y
-func extractChunk(img *image, x int, y int) [][]bool {
-
-	chunk := make([][]bool, 3)
+func extractChunk(img *image, x int, y int) *grid.Grid {
 
-	for dY := -1; dY <= 1; dY++ {
-		chunkY := dY + 1
-		chunk[chunkY] = make([]bool, 3)
-
-		for dX := -1; dX <= 1; dX++ {
-
-			chunkX := dX + 1
-
-			actualY := y + dY
-			actualX := x + dX
-
-			var pixel bool
-
-			if actualY < 0 || actualY >= img.height {
-				pixel = img.infinitePixel
-			} else if actualX < 0 || actualX >= img.width {
-				pixel = img.infinitePixel
-			} else {
-				pixel = img.pixels[actualY][actualX]
-			}
-
-			chunk[chunkY][chunkX] = pixel
+	chunk := grid.New(3, 3)
 
+	chunk.Each(func(p grid.Point, _ int) {
+		pixel, ok := img.pixels.Lookup(grid.Point{X: x + p.X - 1, Y: y + p.Y - 1})
+		if !ok {
+			pixel = img.infinitePixel
 		}
-	}
+		chunk.Set(p, pixel)
+	})
 
 	return chunk
 }
 
 func countLitPixels(img *image) int {
-	if img.infinitePixel {
+	if img.infinitePixel == lit {
 		panic("infinite pixels are lit")
 	}
 
-	var count int
-	for y := 0; y < img.height; y++ {
-		for x := 0; x < img.width; x++ {
-			if img.pixels[y][x] {
-				count++
-			}
-		}
-	}
-	return count
+	return img.pixels.Count(func(pixel int) bool {
+		return pixel == lit
+	})
 }
 
 ////////////////////////////////////////////////////////////////////////////////
 // parseInput
 
-func parseInput(r io.Reader) (image, []bool) {
+func parseInput(r io.Reader) (image, []bool, error) {
 
-	image := image{
-		pixels: make([][]bool, 0),
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return image{}, nil, err
 	}
-	var algorithm []bool
-
-	s := bufio.NewScanner(r)
-	for s.Scan() {
-		l := strings.TrimSpace(s.Text())
-
-		if len(l) == 0 {
-			continue
-		}
-
-		if len(algorithm) == 0 {
-			algorithm = make([]bool, len(l))
-			for i, r := range l {
-				switch r {
-				case '#':
-					algorithm[i] = true
-				case '.':
-					algorithm[i] = false
-				default:
-					panic(fmt.Sprintf("Invalid char in algorithm: %s", string(r)))
-				}
-			}
-			continue
-		}
 
-		image.height++
-		image.width = len(l)
+	// The first line is the algorithm, the rest is the image
+	input := strings.TrimSpace(string(data))
+	lines := strings.SplitN(input, "\n", 2)
 
-		image.pixels = append(image.pixels, make([]bool, image.width))
+	if len(lines) < 2 {
+		return image{}, nil, errors.New("input should have an algorithm followed by an image")
+	}
 
-		for i, r := range l {
-			switch r {
-			case '#':
-				image.pixels[image.height-1][i] = true
-			case '.':
-				image.pixels[image.height-1][i] = false
-			default:
-				panic(fmt.Sprintf("Invalid char in image: %s", string(r)))
-			}
+	algorithmLine := strings.TrimSpace(lines[0])
+	algorithm := make([]bool, len(algorithmLine))
+	for i, r := range algorithmLine {
+		switch r {
+		case lit:
+			algorithm[i] = true
+		case dark:
+			algorithm[i] = false
+		default:
+			return image{}, nil, fmt.Errorf("invalid char in algorithm: %s", string(r))
 		}
 	}
 
-	return image, algorithm
-}
+	if len(algorithm) != 512 {
+		return image{}, nil, fmt.Errorf("algorithm should be 512 characters, not %d", len(algorithm))
+	}
 
-func printPixels(pixels *[][]bool) string {
-	sb := strings.Builder{}
-	for y := 0; y < len(*pixels); y++ {
-		if sb.Len() > 0 {
-			sb.WriteString("\n")
-		}
-		for x := 0; x < len((*pixels)[y]); x++ {
-			pixel := (*pixels)[y][x]
-			if pixel {
-				sb.WriteString("#")
-			} else {
-				sb.WriteString(".")
-			}
-		}
+	pixels, err := grid.ParseRunes(strings.NewReader(lines[1]), string([]rune{lit, dark}))
+	if err != nil {
+		return image{}, nil, fmt.Errorf("image: %w", err)
 	}
-	return sb.String()
+
+	return image{pixels, dark}, algorithm, nil
 }
@@ -4,17 +4,19 @@ import (
 	_ "embed"
 	"strings"
 	"testing"
+
+	"github.com/matthinz/aoc-golang/grid"
 )
 
 //go:embed input
 var actualRealInput string
 
 func TestChunkToIndex(t *testing.T) {
-	chunk := [][]bool{
-		{false, false, false},
-		{true, false, false},
-		{false, true, false},
-	}
+	chunk := grid.FromRows([][]int{
+		{dark, dark, dark},
+		{lit, dark, dark},
+		{dark, lit, dark},
+	})
 	expected := 34
 	actual := chunkToIndex(chunk)
 	if actual != expected {
@@ -25,13 +27,9 @@ func TestChunkToIndex(t *testing.T) {
 func TestExtractChunk(t *testing.T) {
 
 	img := image{
-		width:  3,
-		height: 3,
-		pixels: [][]bool{
-			{true, true, true},
-			{true, true, true},
-			{true, true, true},
-		}}
+		pixels:        grid.Filled(3, 3, lit),
+		infinitePixel: dark,
+	}
 
 	chunk := extractChunk(&img, -1, -1)
 
@@ -41,7 +39,7 @@ func TestExtractChunk(t *testing.T) {
 ..#
 		`)
 
-	actual := printPixels(&chunk)
+	actual := chunk.RenderRunes()
 
 	if actual != expected {
 		t.Log(actual)
@@ -61,7 +59,10 @@ func TestEnhance(t *testing.T) {
 ..###
 `)
 
-	img, algorithm := parseInput(strings.NewReader(input))
+	img, algorithm, err := parseInput(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
 
 	enhanced := enhance(&img, algorithm)
 
@@ -69,7 +70,7 @@ func TestEnhance(t *testing.T) {
 	actual := countLitPixels(enhanced)
 
 	if actual != expected {
-		t.Log(printPixels(&enhanced.pixels))
+		t.Log(enhanced.pixels.RenderRunes())
 		t.Errorf("Wrong # of lit pixels. Expected %d, got %d", expected, actual)
 	}
 
@@ -79,7 +80,7 @@ func TestEnhance(t *testing.T) {
 	actual = countLitPixels(enhanced)
 
 	if actual != expected {
-		t.Log(printPixels(&enhanced.pixels))
+		t.Log(enhanced.pixels.RenderRunes())
 		t.Errorf("Wrong # of lit pixels after 2nd enhance. Expected %d, got %d", expected, actual)
 	}
 
@@ -87,7 +88,10 @@ func TestEnhance(t *testing.T) {
 
 func TestEnhanceWithRealInput(t *testing.T) {
 
-	img, algorithm := parseInput(strings.NewReader(actualRealInput))
+	img, algorithm, err := parseInput(strings.NewReader(actualRealInput))
+	if err != nil {
+		t.Fatal(err)
+	}
 
 	enhanced := enhance(&img, algorithm)
 	enhanced = enhance(enhanced, algorithm)
@@ -2,9 +2,9 @@ package d21
 
 import (
 	"bufio"
+	"context"
 	_ "embed"
 	"io"
-	"log"
 	"regexp"
 	"strconv"
 	"strings"
@@ -45,11 +45,18 @@ const boardSize = 10
 //go:embed input
 var defaultInput string
 
+//go:embed answers.json
+var answers string
+
+func init() {
+	aoc.Register(2021, New())
+}
+
 func New() aoc.Day {
-	return aoc.NewDay(21, defaultInput, Puzzle1, Puzzle2)
+	return aoc.NewDay(21, defaultInput, Puzzle1, Puzzle2).WithAnswers(answers)
 }
 
-func Puzzle1(r io.Reader, l *log.Logger) string {
+func Puzzle1(ctx context.Context, r io.Reader, l *aoc.Logger) (string, error) {
 	game := parseInput(r)
 	game.die = createDeterministicDie(100)
 
@@ -57,10 +64,10 @@ func Puzzle1(r io.Reader, l *log.Logger) string {
 
 	result := loser.score * game.rollCount
 
-	return strconv.Itoa(result)
+	return strconv.Itoa(result), nil
 }
 
-func Puzzle2(r io.Reader, l *log.Logger) string {
+func Puzzle2(ctx context.Context, r io.Reader, l *aoc.Logger) (string, error) {
 	game := parseInput(r)
 
 	result := runQuantumGame(
@@ -69,11 +76,11 @@ func Puzzle2(r io.Reader, l *log.Logger) string {
 	)
 
 	if result.player1Wins > result.player2Wins {
-		return strconv.FormatUint(uint64(result.player1Wins), 10)
+		return strconv.FormatUint(uint64(result.player1Wins), 10), nil
 	} else if result.player2Wins > result.player1Wins {
-		return strconv.FormatUint(uint64(result.player2Wins), 10)
+		return strconv.FormatUint(uint64(result.player2Wins), 10), nil
 	} else {
-		return "tie"
+		return "tie", nil
 	}
 }
 
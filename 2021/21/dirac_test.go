@@ -1,9 +1,11 @@
 package d21
 
 import (
-	"log"
+	"context"
 	"strings"
 	"testing"
+
+	"github.com/matthinz/aoc-golang"
 )
 
 func TestPuzzle1(t *testing.T) {
@@ -11,7 +13,10 @@ func TestPuzzle1(t *testing.T) {
 Player 1 starting position: 4
 Player 2 starting position: 8
 `)
-	actual := Puzzle1(strings.NewReader(input), log.Default())
+	actual, err := Puzzle1(context.Background(), strings.NewReader(input), aoc.DefaultLogger())
+	if err != nil {
+		t.Fatal(err)
+	}
 	expected := "739785"
 	if actual != expected {
 		t.Errorf("Expected %s but got %s", expected, actual)
@@ -23,7 +28,10 @@ func TestPuzzle2(t *testing.T) {
 Player 1 starting position: 4
 Player 2 starting position: 8
 `)
-	actual := Puzzle2(strings.NewReader(input), log.Default())
+	actual, err := Puzzle2(context.Background(), strings.NewReader(input), aoc.DefaultLogger())
+	if err != nil {
+		t.Fatal(err)
+	}
 	expected := "444356092776315"
 	if actual != expected {
 		t.Errorf("Expected %s but got %s", expected, actual)
@@ -2,27 +2,22 @@ package d22
 
 import (
 	"bufio"
+	"context"
 	_ "embed"
 	"fmt"
 	"io"
-	"log"
 	"regexp"
 	"sort"
 	"strconv"
 	"strings"
-	"time"
 
 	"github.com/matthinz/aoc-golang"
+	"github.com/matthinz/aoc-golang/geom"
 )
 
-type point struct {
-	x, y, z int
-}
-
 type cuboid struct {
-	position point
-	size     point
-	on       bool
+	box geom.Box
+	on  bool
 }
 
 type interval struct {
@@ -39,105 +34,113 @@ type interval struct {
 //go:embed input
 var defaultInput string
 
+//go:embed answers.json
+var answers string
+
+func init() {
+	aoc.Register(2021, New())
+}
+
 func New() aoc.Day {
-	return aoc.NewDay(22, defaultInput, Puzzle1, Puzzle2)
+	return aoc.NewDay(22, defaultInput, Puzzle1, Puzzle2).WithAnswers(answers)
 }
 
-func Puzzle1(r io.Reader, l *log.Logger) string {
+func Puzzle1(ctx context.Context, r io.Reader, l *aoc.Logger) (string, error) {
 
 	cuboids := parseInput(r)
 
 	initializationCuboids := make([]cuboid, 0)
 	for _, c := range cuboids {
-		if c.position.x < -50 || c.position.x > 50 {
+		if c.box.Min.X < -50 || c.box.Min.X > 50 {
 			continue
 		}
-		if c.position.y < -50 || c.position.y > 50 {
+		if c.box.Min.Y < -50 || c.box.Min.Y > 50 {
 			continue
 		}
-		if c.position.z < -50 || c.position.z > 50 {
+		if c.box.Min.Z < -50 || c.box.Min.Z > 50 {
 			continue
 		}
 		initializationCuboids = append(initializationCuboids, c)
 	}
 
-	normalized := initializeReactor(initializationCuboids, l)
-
-	ct := countCubesOn(normalized)
+	ct, err := initializeReactor(ctx, initializationCuboids, l)
+	if err != nil {
+		return "", err
+	}
 
-	return strconv.FormatUint(uint64(ct), 10)
+	return strconv.FormatUint(uint64(ct), 10), nil
 }
 
-func Puzzle2(r io.Reader, l *log.Logger) string {
+func Puzzle2(ctx context.Context, r io.Reader, l *aoc.Logger) (string, error) {
 	cuboids := parseInput(r)
 
 	l.Printf("Parsed %d cuboids from input", len(cuboids))
 
-	normalized := initializeReactor(cuboids, l)
-
-	l.Printf("Normalized into %d cuboids", len(normalized))
-
-	ct := countCubesOn(normalized)
+	ct, err := initializeReactor(ctx, cuboids, l)
+	if err != nil {
+		return "", err
+	}
 
-	return strconv.FormatUint(uint64(ct), 10)
+	return strconv.FormatUint(uint64(ct), 10), nil
 }
 
 ////////////////////////////////////////////////////////////////////////////////
 // Non-brute force solution
 
-// takes a set of cuboids and returns a normalized set of non-overlapping
-// cuboids that have been turned on
-func initializeReactor(cuboids []cuboid, l *log.Logger) []cuboid {
+// takes a set of cuboids, normalizes them into non-overlapping cuboids and
+// returns how many cubes are left on, reporting each combination of intervals
+// checked to ctx's progress. The normalized cuboids are only counted, not
+// kept: the full input has hundreds of millions of them. It gives up with
+// ctx.Err() if ctx is done first.
+func initializeReactor(ctx context.Context, cuboids []cuboid, l *aoc.Logger) (uint, error) {
+	progress := aoc.ProgressFrom(ctx)
 
 	xIntervals := buildIntervals(
 		cuboids,
 		func(c cuboid) int {
-			return c.position.x
+			return c.box.Min.X
 		},
 		func(c cuboid) int {
-			return c.size.x
+			return c.box.Size().X
 		},
 	)
 
 	yIntervals := buildIntervals(
 		cuboids,
 		func(c cuboid) int {
-			return c.position.y
+			return c.box.Min.Y
 		},
 		func(c cuboid) int {
-			return c.size.y
+			return c.box.Size().Y
 		},
 	)
 
 	zIntervals := buildIntervals(
 		cuboids,
 		func(c cuboid) int {
-			return c.position.z
+			return c.box.Min.Z
 		},
 		func(c cuboid) int {
-			return c.size.z
+			return c.box.Size().Z
 		},
 	)
 
-	log.Printf("%d x intervals, %d y intervals, %d z intervals (=%d combos)", len(xIntervals), len(yIntervals), len(zIntervals), len(xIntervals)*len(yIntervals)*len(zIntervals))
+	l.Printf("%d x intervals, %d y intervals, %d z intervals (=%d combos)", len(xIntervals), len(yIntervals), len(zIntervals), len(xIntervals)*len(yIntervals)*len(zIntervals))
 
-	result := make([]cuboid, 0)
+	var ct uint
+	pieces := 0
 
-	processed := 0
-	start := time.Now()
+	progress.SetTotal(int64(len(xIntervals) * len(yIntervals) * len(zIntervals)))
 
 	for _, xInterval := range xIntervals {
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+
 		for _, yInterval := range yIntervals {
 			for _, zInterval := range zIntervals {
 
-				processed++
-				if processed%1000000 == 0 {
-					elapsed := time.Now().Sub(start)
-					msPer := float64(elapsed.Milliseconds()) / float64(processed)
-					remaining := (len(xIntervals) * len(yIntervals) * len(zIntervals)) - processed
-					msRemaining := float64(remaining) * msPer
-					l.Printf("Processed %d intervals (%fms per; %d hit(s); ~%ds remain)", processed, msPer, len(result), int(msRemaining/1000))
-				}
+				progress.Add(1)
 
 				cuboidIndices := intersection(xInterval.cuboidIndices, yInterval.cuboidIndices, zInterval.cuboidIndices)
 
@@ -152,36 +155,25 @@ func initializeReactor(cuboids []cuboid, l *log.Logger) []cuboid {
 					continue
 				}
 
-				// ok so now we have x, y, and z values
+				// ok so now we have x, y, and z values (interval ends are exclusive,
+				// box corners are not)
 				c := cuboid{
-					position: point{
-						x: xInterval.start,
-						y: yInterval.start,
-						z: zInterval.start,
-					},
-					size: point{
-						x: xInterval.end - xInterval.start,
-						y: yInterval.end - yInterval.start,
-						z: zInterval.end - zInterval.start,
+					box: geom.Box{
+						Min: geom.Point3{X: xInterval.start, Y: yInterval.start, Z: zInterval.start},
+						Max: geom.Point3{X: xInterval.end - 1, Y: yInterval.end - 1, Z: zInterval.end - 1},
 					},
 					on: true,
 				}
 
-				result = append(result, c)
+				ct += uint(c.box.Volume())
+				pieces++
 			}
 		}
 	}
 
-	return result
-}
-
-func countCubesOn(cuboids []cuboid) uint {
-	var ct uint
+	l.Printf("Normalized into %d cuboids", pieces)
 
-	for _, c := range cuboids {
-		ct += (uint(c.size.x) * uint(c.size.y) * uint(c.size.z))
-	}
-	return ct
+	return ct, nil
 }
 
 // given a set of cuboids along with functions to pull off specific coordinate
@@ -395,14 +387,10 @@ func parseCuboid(x1, x2, y1, y2, z1, z2 string) (cuboid, error) {
 	}
 
 	c := cuboid{
-		position: point{
-			values[0], values[2], values[4],
-		},
-		size: point{
-			values[1] - values[0] + 1,
-			values[3] - values[2] + 1,
-			values[5] - values[4] + 1,
-		},
+		box: geom.NewBox(
+			geom.Point3{X: values[0], Y: values[2], Z: values[4]},
+			geom.Point3{X: values[1], Y: values[3], Z: values[5]},
+		),
 	}
 
 	return c, nil
@@ -1,9 +1,11 @@
 package d22
 
 import (
-	"log"
+	"context"
 	"strings"
 	"testing"
+
+	"github.com/matthinz/aoc-golang"
 )
 
 func TestParseInput(t *testing.T) {
@@ -25,14 +27,14 @@ func TestParseInput(t *testing.T) {
 		t.Error("First step should turn on")
 	}
 
-	if c.size.x != 3 {
-		t.Errorf("First step cuboid should have x dimension 3, but was %d", c.size.x)
+	if c.box.Size().X != 3 {
+		t.Errorf("First step cuboid should have x dimension 3, but was %d", c.box.Size().X)
 	}
-	if c.size.y != 3 {
-		t.Errorf("First step cuboid should have y dimension 3, but was %d", c.size.y)
+	if c.box.Size().Y != 3 {
+		t.Errorf("First step cuboid should have y dimension 3, but was %d", c.box.Size().Y)
 	}
-	if c.size.z != 3 {
-		t.Errorf("First step cuboid should have z dimension 3, but was %d", c.size.z)
+	if c.box.Size().Z != 3 {
+		t.Errorf("First step cuboid should have z dimension 3, but was %d", c.box.Size().Z)
 	}
 }
 
@@ -46,10 +48,10 @@ func TestBuildIntervals(t *testing.T) {
 	xIntervals := buildIntervals(
 		cuboids,
 		func(c cuboid) int {
-			return c.position.x
+			return c.box.Min.X
 		},
 		func(c cuboid) int {
-			return c.size.x
+			return c.box.Size().X
 		},
 	)
 
@@ -116,11 +118,17 @@ func TestInitializationWithoutBruteForce(t *testing.T) {
 
 	t.Logf("Parsed %d cuboids from input", len(cuboids))
 
-	normalized := initializeReactor(cuboids, log.Default())
+	progress := aoc.NewTracker()
+	ctx := aoc.WithProgress(context.Background(), progress)
 
-	t.Logf("Normalized into %d cuboids", len(normalized))
+	ct, err := initializeReactor(ctx, cuboids, aoc.DefaultLogger())
+	if err != nil {
+		t.Fatal(err)
+	}
 
-	ct := countCubesOn(normalized)
+	if status := progress.Status(); status.Total == 0 || status.Done != status.Total {
+		t.Errorf("Expected progress to reach its total, got %s", status)
+	}
 
 	expected := uint(39)
 
@@ -128,3 +136,14 @@ func TestInitializationWithoutBruteForce(t *testing.T) {
 		t.Errorf("Expected %d, but got %d", expected, ct)
 	}
 }
+
+func TestInitializeReactorCanceled(t *testing.T) {
+	cuboids := parseInput(strings.NewReader("on x=10..12,y=10..12,z=10..12\n"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := initializeReactor(ctx, cuboids, aoc.DiscardLogger()); err != context.Canceled {
+		t.Errorf("Expected %v, got %v", context.Canceled, err)
+	}
+}
@@ -2,16 +2,18 @@ package d23
 
 import (
 	"bufio"
+	"context"
 	_ "embed"
+	"errors"
 	"fmt"
 	"io"
-	"log"
 	"math"
 	"regexp"
 	"strconv"
 	"strings"
 
 	"github.com/matthinz/aoc-golang"
+	"github.com/matthinz/aoc-golang/search"
 )
 
 type amphipodKind rune
@@ -36,11 +38,7 @@ type game struct {
 }
 
 type gameState struct {
-	parent     *gameState
-	lastMove   *move
-	totalCost  int
-	totalMoves int
-	positions  []*amphipod
+	positions []*amphipod
 }
 
 type move struct {
@@ -58,103 +56,132 @@ const (
 //go:embed input
 var defaultInput string
 
+//go:embed answers.json
+var answers string
+
+func init() {
+	aoc.Register(2021, New())
+}
+
 func New() aoc.Day {
-	return aoc.NewDay(23, defaultInput, Puzzle1, Puzzle2)
+	return aoc.NewDay(23, defaultInput, Puzzle1, Puzzle2).WithAnswers(answers)
 }
 
-func Puzzle1(r io.Reader, l *log.Logger) string {
+func Puzzle1(ctx context.Context, r io.Reader, l *aoc.Logger) (string, error) {
 	g := parseInput(r)
 
-	solvedState, statesEvaluated := solve(&g, &g.initialState, nil, l, 0)
-
-	l.Printf("Evaluated %d total states", statesEvaluated)
-
-	if solvedState == nil {
-		panic("No solution found")
-	}
-
-	var moves []move
-	for s := solvedState; s != nil; s = s.parent {
-		if s.lastMove != nil {
-			moves = append(moves, *s.lastMove)
-		}
-	}
-
-	for i := len(moves) - 1; i >= 0; i-- {
-		fmt.Printf("%d -> %d (%d)\n", moves[i].from, moves[i].to, moves[i].cost)
+	totalCost, err := solve(ctx, &g, l)
+	if err != nil {
+		return "", err
 	}
 
-	return strconv.Itoa(solvedState.totalCost)
+	return strconv.Itoa(totalCost), nil
 }
 
-func Puzzle2(r io.Reader, l *log.Logger) string {
+func Puzzle2(ctx context.Context, r io.Reader, l *aoc.Logger) (string, error) {
 
 	input := unfoldDiagram(r)
 
 	g := parseInput(strings.NewReader(input))
 
-	solvedState, statesEvaluated := solve(&g, &g.initialState, nil, l, 0)
+	totalCost, err := solve(ctx, &g, l)
+	if err != nil {
+		return "", err
+	}
 
-	l.Printf("Evaluated %d total states", statesEvaluated)
+	return strconv.Itoa(totalCost), nil
+}
 
-	if solvedState == nil {
-		panic("No solution found")
-	}
+////////////////////////////////////////////////////////////////////////////////
+// Part 1 solution
 
-	var moves []move
-	for s := solvedState; s != nil; s = s.parent {
-		if s.lastMove != nil {
-			moves = append(moves, *s.lastMove)
+// solve searches for the cheapest way to get from the game's initial state to
+// a solved one, logging the moves that make it up. It stops early with
+// ctx.Err() if ctx is done before the search completes.
+func solve(ctx context.Context, g *game, l *aoc.Logger) (int, error) {
+	// States are searched using their keys, since gameStates aren't comparable
+	graph := search.GraphFunc(func(s search.State) []search.Edge {
+		state := stateFromKey(s.(string))
+		moves := getLegalMoves(g, state)
+
+		edges := make([]search.Edge, len(moves))
+		for i, m := range moves {
+			edges[i] = search.Edge{
+				To:   applyMove(g, state, m).key(),
+				Cost: m.cost,
+			}
 		}
+		return edges
+	})
+
+	isSolvedKey := func(s search.State) bool {
+		return isSolved(g, stateFromKey(s.(string)))
 	}
 
-	for i := len(moves) - 1; i >= 0; i-- {
-		fmt.Printf("%d -> %d (%d)\n", moves[i].from, moves[i].to, moves[i].cost)
+	result, err := search.Dijkstra(ctx, graph, g.initialState.key(), isSolvedKey)
+	if errors.Is(err, search.ErrNoPath) {
+		return 0, errors.New("no solution found")
+	} else if err != nil {
+		return 0, err
 	}
 
-	return strconv.Itoa(solvedState.totalCost)
-}
+	l.Printf("Evaluated %d total states", result.Expanded)
 
-////////////////////////////////////////////////////////////////////////////////
-// Part 1 solution
+	for i := 1; i < len(result.Path); i++ {
+		m := moveBetween(
+			stateFromKey(result.Path[i-1].State.(string)),
+			stateFromKey(result.Path[i].State.(string)),
+		)
+		l.Debugf("%d -> %d (%d)", m.from, m.to, result.Path[i].Cost-result.Path[i-1].Cost)
+	}
 
-func solve(g *game, state *gameState, bestSolution *gameState, l *log.Logger, statesEvaluated uint) (*gameState, uint) {
-	moves := getLegalMoves(g, state)
-	for move := range moves {
-		statesEvaluated++
+	return result.Cost, nil
+}
 
-		if bestSolution != nil && state.totalCost+move.cost >= bestSolution.totalCost {
-			continue
+// key returns a string identifying state, with one character per position:
+// the kind of amphipod there, or '.' if it is empty
+func (state *gameState) key() string {
+	b := make([]byte, len(state.positions))
+	for pos, a := range state.positions {
+		if a == nil {
+			b[pos] = '.'
+		} else {
+			b[pos] = byte(a.kind)
 		}
+	}
+	return string(b)
+}
 
-		nextState := applyMove(g, state, move)
-		if isSolved(g, nextState) {
-			if bestSolution == nil || nextState.totalCost < bestSolution.totalCost {
-				bestSolution = nextState
-				l.Printf("*** New best solution: %d (%d moves)", bestSolution.totalCost, bestSolution.totalMoves)
-			}
-			continue
+// stateFromKey is the inverse of key
+func stateFromKey(key string) *gameState {
+	state := gameState{
+		positions: make([]*amphipod, len(key)),
+	}
+	for pos, c := range []byte(key) {
+		if c != '.' {
+			state.positions[pos] = &amphipod{kind: amphipodKind(c)}
 		}
+	}
+	return &state
+}
 
-		solution, substatesEvaluated := solve(g, nextState, bestSolution, l, 0)
-		statesEvaluated += substatesEvaluated
-		if solution != nil {
-			if bestSolution == nil || solution.totalCost < bestSolution.totalCost {
-				bestSolution = solution
-			}
+// moveBetween works out which move (ignoring cost) turns from into to
+func moveBetween(from, to *gameState) move {
+	var m move
+	for pos := range from.positions {
+		switch {
+		case from.positions[pos] != nil && to.positions[pos] == nil:
+			m.from = pos
+		case from.positions[pos] == nil && to.positions[pos] != nil:
+			m.to = pos
 		}
 	}
-
-	return bestSolution, statesEvaluated
+	return m
 }
 
 func applyMove(g *game, state *gameState, m move) *gameState {
 	nextState := gameState{
-		parent:     state,
-		lastMove:   &m,
-		totalCost:  state.totalCost + m.cost,
-		totalMoves: state.totalMoves + 1,
-		positions:  make([]*amphipod, len(state.positions)),
+		positions: make([]*amphipod, len(state.positions)),
 	}
 
 	copy(nextState.positions, state.positions)
@@ -199,39 +226,23 @@ func getDestinationRoomIndex(a *amphipod) int {
 
 }
 
-func getLegalMoves(g *game, state *gameState) chan move {
-	ch := make(chan move)
-
-	go func() {
-		defer close(ch)
+// getLegalMoves returns every move that can be made from state. Amber
+// amphipods' moves come first, then bronze, copper and desert.
+func getLegalMoves(g *game, state *gameState) []move {
+	var moves []move
 
+	for _, kind := range []amphipodKind{AmberAmphipod, BronzeAmphipod, CopperAmphipod, DesertAmphipod} {
 		for pos, a := range state.positions {
-			if a != nil && a.kind == AmberAmphipod {
-				findLegalMovesForAmphipod(g, state, a, pos, ch)
-			}
-		}
-		for pos, a := range state.positions {
-			if a != nil && a.kind == BronzeAmphipod {
-				findLegalMovesForAmphipod(g, state, a, pos, ch)
+			if a != nil && a.kind == kind {
+				moves = append(moves, findLegalMovesForAmphipod(g, state, a, pos)...)
 			}
 		}
-		for pos, a := range state.positions {
-			if a != nil && a.kind == CopperAmphipod {
-				findLegalMovesForAmphipod(g, state, a, pos, ch)
-			}
-		}
-		for pos, a := range state.positions {
-			if a != nil && a.kind == DesertAmphipod {
-				findLegalMovesForAmphipod(g, state, a, pos, ch)
-			}
-		}
-
-	}()
+	}
 
-	return ch
+	return moves
 }
 
-func findLegalMovesForAmphipod(g *game, state *gameState, a *amphipod, pos int, ch chan move) {
+func findLegalMovesForAmphipod(g *game, state *gameState, a *amphipod, pos int) []move {
 
 	workingPositions := state.positions
 
@@ -256,27 +267,28 @@ func findLegalMovesForAmphipod(g *game, state *gameState, a *amphipod, pos int,
 
 	if movedIntoDestinationRoom {
 		// We made it to the best place in the destination room, and this is the only move that matters.
-		ch <- move{
+		return []move{{
 			from: startingPos,
 			to:   destRoomPos,
 			cost: moveToHallwayCost + moveToDestRoomCost,
-		}
-		return
+		}}
 	}
 
 	if movedIntoHallway {
 		// We need to move this amphipod to a valid place in the hallway, otherwise it won't stick
 		accessibleHallwayPositions := tryMoveAmphipodToValidPositionInHallway(g, a, hallwayPos, workingPositions)
+		moves := make([]move, 0, len(accessibleHallwayPositions))
 		for _, newHallwayPos := range accessibleHallwayPositions {
-			ch <- move{
+			moves = append(moves, move{
 				from: startingPos,
 				to:   newHallwayPos,
 				cost: moveToHallwayCost + costToMove(a, int(math.Abs(float64(hallwayPos-newHallwayPos)))),
-			}
+			})
 		}
-		return
+		return moves
 	}
 
+	return nil
 }
 
 // attempts to move <a> from <hallwayPos> to another position in the hallway
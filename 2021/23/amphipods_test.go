@@ -234,16 +234,7 @@ func TestFindLegalMovesForAmphipodInFirstPosition(t *testing.T) {
 		t.Fatal("Could not find target")
 	}
 
-	ch := make(chan move)
-	go func() {
-		defer close(ch)
-		findLegalMovesForAmphipod(&g, &g.initialState, target, targetPos, ch)
-	}()
-
-	moves := make([]move, 0)
-	for m := range ch {
-		moves = append(moves, m)
-	}
+	moves := findLegalMovesForAmphipod(&g, &g.initialState, target, targetPos)
 
 	expected := 7
 
@@ -282,16 +273,7 @@ func TestFindLegalMovesForAmphipodForGuysTuckedDeepInRooms(t *testing.T) {
 		t.Fatal("Could not find target")
 	}
 
-	ch := make(chan move)
-	go func() {
-		defer close(ch)
-		findLegalMovesForAmphipod(&g, &g.initialState, target, targetPos, ch)
-	}()
-
-	moves := make([]move, 0)
-	for m := range ch {
-		moves = append(moves, m)
-	}
+	moves := findLegalMovesForAmphipod(&g, &g.initialState, target, targetPos)
 
 	expected := 3
 
@@ -459,15 +441,11 @@ func TestRunThroughExample(t *testing.T) {
 			t.Errorf("Test %d: No amphipod found at position %d (expected %s)", testIndex, test.from, string(test.kind))
 		}
 
-		ch := make(chan move)
-		go func() {
-			defer close(ch)
-			findLegalMovesForAmphipod(&g, &g.initialState, a, test.from, ch)
-		}()
+		moves := findLegalMovesForAmphipod(&g, &g.initialState, a, test.from)
 
 		foundMove := false
 
-		for m := range ch {
+		for _, m := range moves {
 			if m.from == test.from && m.to == test.to {
 				if m.cost != test.cost {
 					t.Errorf("Test %d: Found move from %d to %d, but cost was wrong (expected %d, got %d)", testIndex, test.from, test.to, test.cost, m.cost)
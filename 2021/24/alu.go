@@ -1,9 +1,9 @@
 package d24
 
 import (
+	"context"
 	_ "embed"
 	"io"
-	"log"
 	"strconv"
 
 	"github.com/matthinz/aoc-golang"
@@ -12,20 +12,30 @@ import (
 //go:embed input
 var defaultInput string
 
+//go:embed answers.json
+var answers string
+
+func init() {
+	aoc.Register(2021, New())
+}
+
 func New() aoc.Day {
-	return aoc.NewDay(24, defaultInput, Puzzle1, Puzzle2)
+	return aoc.NewDay(24, defaultInput, Puzzle1, Puzzle2).WithAnswers(answers)
 }
 
-func Puzzle1(r io.Reader, l *log.Logger) string {
+func Puzzle1(ctx context.Context, r io.Reader, l *aoc.Logger) (string, error) {
 
 	l.Printf("parsing...")
-	reg := parseInput(r)
+	reg, err := parseInput(r)
+	if err != nil {
+		return "", err
+	}
 	l.Printf("parse completed")
 
-	inputs, err := SolveForLargest(reg.z, 0, l)
+	inputs, err := SolveForLargest(ctx, reg.z, 0, l)
 
 	if err != nil {
-		panic(err)
+		return "", err
 	}
 
 	result := ""
@@ -33,18 +43,21 @@ func Puzzle1(r io.Reader, l *log.Logger) string {
 		result += strconv.Itoa(inputs[i])
 	}
 
-	return result
+	return result, nil
 }
 
-func Puzzle2(r io.Reader, l *log.Logger) string {
+func Puzzle2(ctx context.Context, r io.Reader, l *aoc.Logger) (string, error) {
 	l.Printf("parsing...")
-	reg := parseInput(r)
+	reg, err := parseInput(r)
+	if err != nil {
+		return "", err
+	}
 	l.Printf("parse completed")
 
-	inputs, err := SolveForSmallest(reg.z, 0, l)
+	inputs, err := SolveForSmallest(ctx, reg.z, 0, l)
 
 	if err != nil {
-		panic(err)
+		return "", err
 	}
 
 	result := ""
@@ -52,5 +65,5 @@ func Puzzle2(r io.Reader, l *log.Logger) string {
 		result += strconv.Itoa(inputs[i])
 	}
 
-	return result
+	return result, nil
 }
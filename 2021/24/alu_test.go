@@ -9,7 +9,10 @@ import (
 
 func TestFindAllInputsInZ(t *testing.T) {
 	t.Skip()
-	reg := parseInput(strings.NewReader(realInput))
+	reg, err := parseInput(strings.NewReader(realInput))
+	if err != nil {
+		t.Fatal(err)
+	}
 	expr := reg.z.Simplify([]int{})
 	inputs := make(map[int]int)
 	expr.Accept(func(e Expression) {
@@ -29,7 +32,10 @@ func TestFindAllInputsInZ(t *testing.T) {
 }
 
 func BenchmarkSimplify(b *testing.B) {
-	reg := parseInput(strings.NewReader(realInput))
+	reg, err := parseInput(strings.NewReader(realInput))
+	if err != nil {
+		b.Fatal(err)
+	}
 	for i := 0; i < b.N; i++ {
 		reg.z.Simplify([]int{})
 	}
@@ -40,7 +46,10 @@ func TestFirstExample(t *testing.T) {
 	input := strings.TrimSpace(`
 inp x
 mul x -1`)
-	reg := parseInput(strings.NewReader(input))
+	reg, err := parseInput(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
 
 	tests := map[int]int{
 		0:    0,
@@ -72,7 +81,10 @@ inp z
 inp x
 mul z 3
 eql z x`)
-	reg := parseInput(strings.NewReader(input))
+	reg, err := parseInput(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
 
 	tests := map[[2]int]int{
 		{1, 3}: 1,
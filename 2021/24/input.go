@@ -3,6 +3,7 @@ package d24
 import (
 	"fmt"
 	"sort"
+	"sync"
 )
 
 type InputExpression struct {
@@ -15,9 +16,16 @@ const MaxInputValue = 9
 
 var inputRange = newContinuousRange(MinInputValue, MaxInputValue, 1)
 
+// inputsByIndex is shared by every puzzle running in the process, so it is
+// guarded by inputsMutex
 var inputsByIndex = make(map[int]*InputExpression)
 
+var inputsMutex sync.Mutex
+
 func NewInputExpression(index int) Expression {
+	inputsMutex.Lock()
+	defer inputsMutex.Unlock()
+
 	expr, ok := inputsByIndex[index]
 	if ok {
 		return expr
@@ -1,11 +1,10 @@
 package d24
 
 import (
-	"bufio"
-	"fmt"
 	"io"
-	"strconv"
-	"strings"
+	"regexp"
+
+	"github.com/matthinz/aoc-golang/parse"
 )
 
 var expressionFactories = map[string]func(expressions ...interface{}) Expression{
@@ -16,70 +15,81 @@ var expressionFactories = map[string]func(expressions ...interface{}) Expression
 	"mul": NewMultiplyExpression,
 }
 
-func parseInput(r io.Reader) *Registers {
+// instructionPattern splits an instruction into its operation and one or two
+// arguments
+var instructionPattern = regexp.MustCompile(`^\s*(\S+)\s+(\S+)(?:\s+(\S+))?\s*$`)
+
+func parseInput(r io.Reader) (*Registers, error) {
 
 	result := NewRegisters()
 
 	inputIndex := 0
 
-	s := bufio.NewScanner(r)
-	lineIndex := 0
+	records, err := parse.Records(r, instructionPattern)
+	if err != nil {
+		return nil, err
+	}
 
-	for s.Scan() {
-		line := strings.TrimSpace(s.Text())
-		lineIndex++
+	for _, record := range records {
+		op, register, arg := record.Fields[0], record.Fields[1], record.Fields[2]
 
-		if len(line) == 0 {
-			continue
+		if !isRegister(register) {
+			return nil, record.Errorf(1, "invalid register: %s", register)
 		}
 
-		parts := strings.Split(line, " ")
-
-		if len(parts) == 2 {
-
-			// this must be an "inp"
-			if parts[0] != "inp" {
-				panic(fmt.Sprintf("Invalid unary operation: %s", parts[0]))
+		if op == "inp" {
+			if arg != "" {
+				return nil, record.Errorf(2, "inp takes a single register")
 			}
+
 			expr := NewInputExpression(inputIndex)
 
 			inputIndex++
 
-			result.set(parts[1], expr)
+			result.set(register, expr)
 
 			continue
 		}
 
-		lhs := result.get(parts[1])
+		factory, ok := expressionFactories[op]
+		if !ok {
+			return nil, record.Errorf(0, "invalid op: %s", op)
+		}
+
+		if arg == "" {
+			return nil, record.Line.Errorf(-1, "%s takes a register and a register or number", op)
+		}
+
+		lhs := result.get(register)
 
 		var rhs Expression
 
-		literalValue, err := strconv.ParseInt(parts[2], 10, 32)
-		if err == nil {
-			rhs = NewLiteralExpression(int(literalValue))
+		if isRegister(arg) {
+			rhs = result.get(arg)
 		} else {
-			rhs = result.get(parts[2])
+			literalValue, err := record.Int(2)
+			if err != nil {
+				return nil, err
+			}
+			rhs = NewLiteralExpression(literalValue)
 		}
 
-		expr := makeBinaryExpression(parts[0], lhs, rhs)
+		expr := factory(lhs, rhs)
 
 		// set the value of the specified register to the expression
-		// fmt.Printf("%d: %s \n", lineIndex, line)
 		simplified := expr.Simplify([]int{})
-		// fmt.Println(simplified.String())
-		result.set(parts[1], simplified)
+		result.set(register, simplified)
 
 	}
 
-	return result
+	return result, nil
 }
 
-func makeBinaryExpression(kind string, lhs Expression, rhs Expression) Expression {
-	factory, ok := expressionFactories[kind]
-
-	if !ok {
-		panic(fmt.Sprintf("Invalid op: %s", kind))
+func isRegister(name string) bool {
+	switch name {
+	case "w", "x", "y", "z":
+		return true
+	default:
+		return false
 	}
-
-	return factory(lhs, rhs)
 }
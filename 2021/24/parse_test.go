@@ -15,11 +15,16 @@ func TestParseFirstLinesOfRealInput(t *testing.T) {
 	const LineCount = 170
 	lines := strings.Split(realInput, "\n")
 	first := strings.Join(lines[0:LineCount], "\n")
-	parseInput(strings.NewReader(first))
+	if _, err := parseInput(strings.NewReader(first)); err != nil {
+		t.Fatal(err)
+	}
 }
 
 func TestParseRealInputFindsAllInputsInZ(t *testing.T) {
-	r := parseInput(strings.NewReader(realInput))
+	r, err := parseInput(strings.NewReader(realInput))
+	if err != nil {
+		t.Fatal(err)
+	}
 	inputsFound := make(map[int]int)
 	r.z.Accept(func(e Expression) {
 		ie, ok := e.(*InputExpression)
@@ -38,7 +43,10 @@ func TestParseRealInputFindsAllInputsInZ(t *testing.T) {
 
 func TestParseRealInputTrySolution(t *testing.T) {
 	t.Skip()
-	registers := parseInput(strings.NewReader(realInput))
+	registers, err := parseInput(strings.NewReader(realInput))
+	if err != nil {
+		t.Fatal(err)
+	}
 	solution := []int{9, 8, 7, 1, 4, 3, 9, 3, 4, 9, 7, 9, 3, 3}
 
 	simplified := registers.z.Simplify(solution)
@@ -60,3 +68,21 @@ func TestParseRealInputTrySolution(t *testing.T) {
 	}
 
 }
+
+func TestParseErrors(t *testing.T) {
+	tests := map[string]string{
+		"inp x\nadd x q":     `line 2, column 7: invalid number "q"`,
+		"inp x\nfoo x 1":     "line 2, column 1: invalid op: foo",
+		"inp x\nmul a 2":     "line 2, column 5: invalid register: a",
+		"inp x y":            "line 1, column 7: inp takes a single register",
+		"inp x\n\nadd x":     "line 3: add takes a register and a register or number",
+		"inp x\nadd x 1 2 3": `line 2: "add x 1 2 3" does not match ` + instructionPattern.String(),
+	}
+
+	for input, expected := range tests {
+		_, err := parseInput(strings.NewReader(input))
+		if err == nil || err.Error() != expected {
+			t.Errorf("%q: expected error %q, got %v", input, expected, err)
+		}
+	}
+}
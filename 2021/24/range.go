@@ -3,7 +3,6 @@ package d24
 import (
 	"fmt"
 	"sort"
-	"time"
 )
 
 type Range interface {
@@ -193,19 +192,14 @@ func buildBinaryExpressionRangeValues(
 	op func(lhsValue, rhsValue int) int,
 	context string,
 ) *[]int {
-	started := time.Now()
-
 	values := make(map[int]int)
 	nextLhs := lhs.Values(context)
 
-	steps := 0
-
 	for lhsValue, ok := nextLhs(); ok; lhsValue, ok = nextLhs() {
 		nextRhs := rhs.Values(context)
 		for rhsValue, ok := nextRhs(); ok; rhsValue, ok = nextRhs() {
 			value := op(lhsValue, rhsValue)
 			values[value]++
-			steps++
 		}
 	}
 
@@ -214,13 +208,5 @@ func buildBinaryExpressionRangeValues(
 		uniqueValues = append(uniqueValues, value)
 	}
 
-	duration := time.Now().Sub(started)
-	if duration.Seconds() > 1 {
-		// fmt.Printf("buildBinaryExpressionRangeValues: %v (%d steps, %d unique values)\n", duration, steps, len(uniqueValues))
-		// fmt.Printf("\t%s\n", context)
-		// fmt.Printf("\tlhs: %s\n", lhs.String())
-		// fmt.Printf("\trhs: %s\n", rhs.String())
-	}
-
 	return &uniqueValues
 }
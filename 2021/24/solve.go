@@ -1,16 +1,19 @@
 package d24
 
 import (
+	"context"
 	"fmt"
-	"log"
+
+	"github.com/matthinz/aoc-golang"
 )
 
 // Attempts to solve the given expression, returning a map of input indices to
-// input values required for `expr` to evaluate to `target`.
-func SolveForLargest(expr Expression, target int, l *log.Logger) ([]int, error) {
+// input values required for `expr` to evaluate to `target`. Gives up with
+// ctx.Err() if ctx is done before a solution is found.
+func SolveForLargest(ctx context.Context, expr Expression, target int, l *aoc.Logger) ([]int, error) {
 	initialInputs := []int{}
 
-	inputs, err := solveStep(expr, target, initialInputs, 0, countInputs(expr), MaxInputValue, MinInputValue, -1, l)
+	inputs, err := solveStep(ctx, expr, target, initialInputs, 0, countInputs(expr), MaxInputValue, MinInputValue, -1, l)
 
 	if err != nil {
 		return []int{}, err
@@ -29,10 +32,10 @@ func SolveForLargest(expr Expression, target int, l *log.Logger) ([]int, error)
 	return inputs, nil
 }
 
-func SolveForSmallest(expr Expression, target int, l *log.Logger) ([]int, error) {
+func SolveForSmallest(ctx context.Context, expr Expression, target int, l *aoc.Logger) ([]int, error) {
 	initialInputs := []int{2}
 
-	inputs, err := solveStep(expr, target, initialInputs, 0, countInputs(expr), MinInputValue, MaxInputValue, 1, l)
+	inputs, err := solveStep(ctx, expr, target, initialInputs, 0, countInputs(expr), MinInputValue, MaxInputValue, 1, l)
 
 	if err != nil {
 		return []int{}, err
@@ -61,7 +64,7 @@ func countInputs(expr Expression) int {
 	return len(inputCounts)
 }
 
-func solveStep(expr Expression, target int, inputs []int, index int, inputCount int, inputStartValue, inputEndValue, inputStep int, l *log.Logger) ([]int, error) {
+func solveStep(ctx context.Context, expr Expression, target int, inputs []int, index int, inputCount int, inputStartValue, inputEndValue, inputStep int, l *aoc.Logger) ([]int, error) {
 
 	if len(inputs) >= inputCount {
 		return inputs, nil
@@ -82,7 +85,17 @@ func solveStep(expr Expression, target int, inputs []int, index int, inputCount
 		initialValue = inputStartValue
 	}
 
+	// There's no telling how much of the tree will be searched, so progress
+	// counts the candidates tried without a total
+	progress := aoc.ProgressFrom(ctx)
+
 	for i := initialValue; IsValidInputValue(i); i += inputStep {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		progress.Add(1)
+
 		nextInputs[index] = i
 
 		values := nextInputs[0 : index+1]
@@ -91,7 +104,7 @@ func solveStep(expr Expression, target int, inputs []int, index int, inputCount
 		simplified := expr.Simplify(values)
 		afterCount := countExpressions(simplified)
 
-		l.Printf("trying %v (simplified %d%% from %d to %d nodes)", values, int((float64(beforeCount-afterCount)/float64(beforeCount))*-100), beforeCount, afterCount)
+		l.Debugf("trying %v (simplified %d%% from %d to %d nodes)", values, int((float64(beforeCount-afterCount)/float64(beforeCount))*-100), beforeCount, afterCount)
 
 		r := simplified.Range()
 
@@ -103,7 +116,7 @@ func solveStep(expr Expression, target int, inputs []int, index int, inputCount
 				return values, nil
 			}
 
-			result, err := solveStep(simplified, target, nextInputs, index+1, inputCount, inputStartValue, inputEndValue, inputStep, l)
+			result, err := solveStep(ctx, simplified, target, nextInputs, index+1, inputCount, inputStartValue, inputEndValue, inputStep, l)
 
 			if err == nil {
 				return result, nil
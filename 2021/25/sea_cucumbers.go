@@ -1,163 +1,157 @@
 package d25
 
 import (
-	"bufio"
+	"context"
 	_ "embed"
 	"fmt"
 	"io"
-	"log"
 	"strconv"
-	"strings"
 
 	"github.com/matthinz/aoc-golang"
+	"github.com/matthinz/aoc-golang/grid"
+	"github.com/matthinz/aoc-golang/visual"
 )
 
 //go:embed input
 var defaultInput string
 
-type seaCucumber rune
+//go:embed answers.json
+var answers string
 
 const (
-	noSeaCucumber    seaCucumber = '.'
-	eastSeaCucumber              = '>'
-	southSeaCucumber             = 'v'
+	noSeaCucumber    = '.'
+	eastSeaCucumber  = '>'
+	southSeaCucumber = 'v'
 )
 
+// palette draws empty sea floor (index 0) and each herd (indices 1 and 2)
+var palette = visual.Palette{
+	{Rune: noSeaCucumber, Color: visual.RGB(0, 30, 60)},
+	{Rune: eastSeaCucumber, Color: visual.RGB(80, 220, 120)},
+	{Rune: southSeaCucumber, Color: visual.RGB(240, 160, 60)},
+}
+
+// Directions that each herd moves in
+var (
+	east  = grid.Point{X: 1, Y: 0}
+	south = grid.Point{X: 0, Y: 1}
+)
+
+func init() {
+	aoc.Register(2021, New())
+}
+
 func New() aoc.Day {
-	return aoc.NewDay(25, defaultInput, Puzzle1, Puzzle2)
+	return aoc.NewDay(25, defaultInput, Puzzle1, Puzzle2).WithAnswers(answers)
 }
 
-func Puzzle1(r io.Reader, l *log.Logger) string {
-	board := parseInput(r)
+func Puzzle1(ctx context.Context, r io.Reader, l *aoc.Logger) (string, error) {
+	board, err := parseInput(r)
+	if err != nil {
+		return "", err
+	}
+	rec := visual.RecorderFrom(ctx)
+	record(rec, board, 0)
+
 	move := 0
 	for {
 		move++
 		nextBoard, moved := tick(board)
+		record(rec, nextBoard, move)
 
-		for _, row := range *nextBoard {
-			for _, cell := range row {
-				fmt.Print(string(cell))
-			}
-			fmt.Println()
+		if l.Enabled(aoc.LevelDebug) {
+			l.Debugf("move %d:\n%s\n%d moved", move, nextBoard.RenderRunes(), moved)
 		}
-		fmt.Println(moved)
 
 		if moved == 0 {
-			return strconv.Itoa(move)
+			return strconv.Itoa(move), nil
 		}
 		board = nextBoard
 	}
 }
 
-func Puzzle2(r io.Reader, l *log.Logger) string {
-	return ""
+func Puzzle2(ctx context.Context, r io.Reader, l *aoc.Logger) (string, error) {
+	return "", nil
 }
 
-func parseInput(r io.Reader) *[][]seaCucumber {
-	result := make([][]seaCucumber, 0)
+// parseInput reads the sea floor. Sea cucumbers that move off one edge
+// reappear on the opposite edge, so the board wraps.
+func parseInput(r io.Reader) (*grid.Grid, error) {
+	board, err := grid.ParseRunes(r, string([]rune{noSeaCucumber, eastSeaCucumber, southSeaCucumber}))
+	if err != nil {
+		return nil, err
+	}
+	board.SetWrapping(true)
+	return board, nil
+}
 
-	s := bufio.NewScanner(r)
-	for s.Scan() {
-		line := strings.TrimSpace(s.Text())
-		if len(line) == 0 {
-			continue
-		}
+// record adds a frame showing board after the given move to rec, if there is
+// one
+func record(rec visual.Recorder, board *grid.Grid, move int) {
+	if rec == nil {
+		return
+	}
 
-		row := make([]seaCucumber, len(line))
-		for x, r := range line {
-			switch r {
-			case '.':
-				row[x] = noSeaCucumber
-			case 'v':
-				row[x] = southSeaCucumber
-			case '>':
-				row[x] = eastSeaCucumber
-			default:
-				panic(fmt.Sprintf("invalid char: '%s'", string(r)))
-			}
+	f := visual.GridFrame(board, palette, func(cell int) uint8 {
+		switch cell {
+		case eastSeaCucumber:
+			return 1
+		case southSeaCucumber:
+			return 2
 		}
-		result = append(result, row)
-	}
+		return 0
+	})
+	f.Caption = fmt.Sprintf("move %d", move)
 
-	return &result
+	rec.Record(f)
 }
 
-func tick(board *[][]seaCucumber) (*[][]seaCucumber, int) {
-	nextBoard := make([][]seaCucumber, len(*board))
-
+func tick(board *grid.Grid) (*grid.Grid, int) {
 	// 0. Prepare next board
-	for y := range *board {
-		row := (*board)[y]
-		width := len(row)
-		nextRow := make([]seaCucumber, width)
-		if y == 0 {
-			for x := 0; x < width; x++ {
-				nextRow[x] = noSeaCucumber
-			}
-		} else {
-			copy(nextRow, nextBoard[0])
-		}
-		nextBoard[y] = nextRow
-	}
+	nextBoard := grid.Filled(board.Width(), board.Height(), noSeaCucumber)
+	nextBoard.SetWrapping(true)
 
 	moved := 0
 
 	// 1. process east-facing
-	for y := range *board {
-		row := (*board)[y]
-		width := len(row)
-		for x := range row {
-			cell := row[x]
-
-			if cell != eastSeaCucumber {
-				continue
-			}
-
-			destX := x + 1
-			if destX >= width {
-				destX = 0
-			}
-
-			atDest := row[destX]
-			if atDest == noSeaCucumber {
-				nextBoard[y][destX] = eastSeaCucumber
-				moved++
-			} else {
-				nextBoard[y][x] = eastSeaCucumber
-			}
+	board.Each(func(p grid.Point, cell int) {
+		if cell != eastSeaCucumber {
+			return
 		}
-	}
 
-	// 2. Process south-facing
-	for y := range *board {
-		row := (*board)[y]
-		for x := range row {
-			cell := row[x]
-			if cell != southSeaCucumber {
-				continue
-			}
-
-			destY := y + 1
-			if destY >= len(*board) {
-				destY = 0
-			}
-
-			// We have to make sure there's no south-facing cucumber at our destination
-			// and no east-facing cucumber at the destination on the next
-
-			atDestOnCurrent := (*board)[destY][x]
-			atDestOnNext := nextBoard[destY][x]
-
-			if atDestOnCurrent == southSeaCucumber || atDestOnNext != noSeaCucumber {
-				// we can't move
-				nextBoard[y][x] = southSeaCucumber
-				continue
-			}
-
-			nextBoard[destY][x] = southSeaCucumber
+		dest := p.Add(east)
+
+		if board.Get(dest) == noSeaCucumber {
+			nextBoard.Set(dest, eastSeaCucumber)
 			moved++
+		} else {
+			nextBoard.Set(p, eastSeaCucumber)
 		}
-	}
+	})
+
+	// 2. Process south-facing
+	board.Each(func(p grid.Point, cell int) {
+		if cell != southSeaCucumber {
+			return
+		}
+
+		dest := p.Add(south)
+
+		// We have to make sure there's no south-facing cucumber at our destination
+		// and no east-facing cucumber at the destination on the next
+
+		atDestOnCurrent := board.Get(dest)
+		atDestOnNext := nextBoard.Get(dest)
+
+		if atDestOnCurrent == southSeaCucumber || atDestOnNext != noSeaCucumber {
+			// we can't move
+			nextBoard.Set(p, southSeaCucumber)
+			return
+		}
+
+		nextBoard.Set(dest, southSeaCucumber)
+		moved++
+	})
 
-	return &nextBoard, moved
+	return nextBoard, moved
 }
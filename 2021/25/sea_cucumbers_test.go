@@ -1,9 +1,11 @@
 package d25
 
 import (
-	"log"
+	"context"
 	"strings"
 	"testing"
+
+	"github.com/matthinz/aoc-golang"
 )
 
 func TestExample(t *testing.T) {
@@ -18,7 +20,10 @@ v>v.vv.v..
 v.v..>>v.v
 ....v..v.>
 	`)
-	solution := Puzzle1(strings.NewReader(input), log.Default())
+	solution, err := Puzzle1(context.Background(), strings.NewReader(input), aoc.DefaultLogger())
+	if err != nil {
+		t.Fatal(err)
+	}
 	if solution != "58" {
 		t.Errorf("Expected %d, got %s", 58, solution)
 	}
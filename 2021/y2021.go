@@ -1,61 +1,31 @@
+// Package y2021 imports every 2021 puzzle, registering them with the aoc
+// package. Adding a day means adding its package and an import here.
 package y2021
 
 import (
-	aoc "github.com/matthinz/aoc-golang"
-	d01 "github.com/matthinz/aoc-golang/2021/01"
-	d02 "github.com/matthinz/aoc-golang/2021/02"
-	d03 "github.com/matthinz/aoc-golang/2021/03"
-	d04 "github.com/matthinz/aoc-golang/2021/04"
-	d05 "github.com/matthinz/aoc-golang/2021/05"
-	d06 "github.com/matthinz/aoc-golang/2021/06"
-	d07 "github.com/matthinz/aoc-golang/2021/07"
-	d08 "github.com/matthinz/aoc-golang/2021/08"
-	d09 "github.com/matthinz/aoc-golang/2021/09"
-	d10 "github.com/matthinz/aoc-golang/2021/10"
-	d11 "github.com/matthinz/aoc-golang/2021/11"
-	d12 "github.com/matthinz/aoc-golang/2021/12"
-	d13 "github.com/matthinz/aoc-golang/2021/13"
-	d14 "github.com/matthinz/aoc-golang/2021/14"
-	d15 "github.com/matthinz/aoc-golang/2021/15"
-	d16 "github.com/matthinz/aoc-golang/2021/16"
-	d17 "github.com/matthinz/aoc-golang/2021/17"
-	d18 "github.com/matthinz/aoc-golang/2021/18"
-	d19 "github.com/matthinz/aoc-golang/2021/19"
-	d20 "github.com/matthinz/aoc-golang/2021/20"
-	d21 "github.com/matthinz/aoc-golang/2021/21"
-	d22 "github.com/matthinz/aoc-golang/2021/22"
-	d23 "github.com/matthinz/aoc-golang/2021/23"
-	d24 "github.com/matthinz/aoc-golang/2021/24"
-	d25 "github.com/matthinz/aoc-golang/2021/25"
+	_ "github.com/matthinz/aoc-golang/2021/01"
+	_ "github.com/matthinz/aoc-golang/2021/02"
+	_ "github.com/matthinz/aoc-golang/2021/03"
+	_ "github.com/matthinz/aoc-golang/2021/04"
+	_ "github.com/matthinz/aoc-golang/2021/05"
+	_ "github.com/matthinz/aoc-golang/2021/06"
+	_ "github.com/matthinz/aoc-golang/2021/07"
+	_ "github.com/matthinz/aoc-golang/2021/08"
+	_ "github.com/matthinz/aoc-golang/2021/09"
+	_ "github.com/matthinz/aoc-golang/2021/10"
+	_ "github.com/matthinz/aoc-golang/2021/11"
+	_ "github.com/matthinz/aoc-golang/2021/12"
+	_ "github.com/matthinz/aoc-golang/2021/13"
+	_ "github.com/matthinz/aoc-golang/2021/14"
+	_ "github.com/matthinz/aoc-golang/2021/15"
+	_ "github.com/matthinz/aoc-golang/2021/16"
+	_ "github.com/matthinz/aoc-golang/2021/17"
+	_ "github.com/matthinz/aoc-golang/2021/18"
+	_ "github.com/matthinz/aoc-golang/2021/19"
+	_ "github.com/matthinz/aoc-golang/2021/20"
+	_ "github.com/matthinz/aoc-golang/2021/21"
+	_ "github.com/matthinz/aoc-golang/2021/22"
+	_ "github.com/matthinz/aoc-golang/2021/23"
+	_ "github.com/matthinz/aoc-golang/2021/24"
+	_ "github.com/matthinz/aoc-golang/2021/25"
 )
-
-func New() aoc.Year {
-	return aoc.NewYear(
-		2021,
-		d01.New(),
-		d02.New(),
-		d03.New(),
-		d04.New(),
-		d05.New(),
-		d06.New(),
-		d07.New(),
-		d08.New(),
-		d09.New(),
-		d10.New(),
-		d11.New(),
-		d12.New(),
-		d13.New(),
-		d14.New(),
-		d15.New(),
-		d16.New(),
-		d17.New(),
-		d18.New(),
-		d19.New(),
-		d20.New(),
-		d21.New(),
-		d22.New(),
-		d23.New(),
-		d24.New(),
-		d25.New(),
-	)
-}
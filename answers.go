@@ -0,0 +1,66 @@
+package aoc
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Answers maps part numbers (starting at 1) to the known correct answer for
+// that part.
+type Answers map[int]string
+
+// ParseAnswers reads an answers manifest, which is a JSON object mapping part
+// numbers to answers, e.g.:
+//
+//	{
+//	  "1": "1711",
+//	  "2": "1743"
+//	}
+func ParseAnswers(manifest string) (Answers, error) {
+	var result Answers
+
+	if err := json.Unmarshal([]byte(manifest), &result); err != nil {
+		return nil, fmt.Errorf("invalid answers manifest: %w", err)
+	}
+
+	for part := range result {
+		if part < 1 {
+			return nil, fmt.Errorf("invalid answers manifest: bad part number %d", part)
+		}
+	}
+
+	return result, nil
+}
+
+// Format returns a in the manifest format understood by ParseAnswers
+func (a Answers) Format() string {
+	data, err := json.MarshalIndent(a, "", "  ")
+	if err != nil {
+		// a map[int]string can always be marshaled
+		panic(err)
+	}
+	return string(data) + "\n"
+}
+
+// WithAnswers returns a copy of d that carries the expected answers in
+// manifest (see ParseAnswers). Problems with the manifest are reported by
+// Answers.
+func (d Day) WithAnswers(manifest string) Day {
+	d.answers, d.answersErr = ParseAnswers(manifest)
+	return d
+}
+
+// Answers returns the known correct answers for d's puzzles. Parts with no
+// recorded answer are not present.
+func (d *Day) Answers() (Answers, error) {
+	if d.answersErr != nil {
+		return nil, d.answersErr
+	}
+
+	result := make(Answers, len(d.answers))
+	for part, answer := range d.answers {
+		result[part] = answer
+	}
+
+	return result, nil
+}
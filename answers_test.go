@@ -0,0 +1,78 @@
+package aoc
+
+import (
+	"testing"
+)
+
+func TestParseAnswers(t *testing.T) {
+	answers, err := ParseAnswers(`{"1": "1711", "2": "two\nlines"}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(answers) != 2 {
+		t.Fatalf("Expected 2 answers, got %d", len(answers))
+	}
+
+	if answers[1] != "1711" {
+		t.Errorf("Wrong answer for part 1: %q", answers[1])
+	}
+
+	if answers[2] != "two\nlines" {
+		t.Errorf("Wrong answer for part 2: %q", answers[2])
+	}
+}
+
+func TestParseAnswersInvalid(t *testing.T) {
+	inputs := []string{
+		``,
+		`[]`,
+		`{"one": "1"}`,
+		`{"0": "1"}`,
+	}
+
+	for _, input := range inputs {
+		if _, err := ParseAnswers(input); err == nil {
+			t.Errorf("Expected an error for %q", input)
+		}
+	}
+}
+
+func TestFormatAnswersRoundTrip(t *testing.T) {
+	answers := Answers{1: "123", 2: "X  X\nXXXX"}
+
+	formatted := answers.Format()
+
+	parsed, err := ParseAnswers(formatted)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for part, expected := range answers {
+		if parsed[part] != expected {
+			t.Errorf("Part %d: expected %q, got %q", part, expected, parsed[part])
+		}
+	}
+}
+
+func TestDayWithAnswers(t *testing.T) {
+	d := NewDay(1, "").WithAnswers(`{"2": "42"}`)
+
+	answers, err := d.Answers()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := answers[1]; ok {
+		t.Errorf("Part 1 should not have an answer")
+	}
+
+	if answers[2] != "42" {
+		t.Errorf("Expected 42 for part 2, got %q", answers[2])
+	}
+
+	bad := NewDay(1, "").WithAnswers("nope")
+	if _, err := bad.Answers(); err == nil {
+		t.Errorf("Expected an error for an invalid manifest")
+	}
+}
@@ -1,6 +1,7 @@
 package aoc
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"log"
@@ -8,14 +9,20 @@ import (
 )
 
 // Puzzler is a function that, given a channel of line-oriented input, returns
-// the answer to a puzzle, doing any descriptive logging to log.
-type Puzzler func(r io.Reader, l *log.Logger) string
+// the answer to a puzzle, doing any descriptive logging to log. It returns an
+// error if the puzzle could not be solved. Long-running puzzlers should
+// periodically check ctx and give up once it is done, and can report how far
+// along they are to ProgressFrom(ctx).
+type Puzzler func(ctx context.Context, r io.Reader, l *Logger) (string, error)
 
 // Day represents a single Day of Advent of Code
 type Day struct {
 	number       int
 	defaultInput string
 	puzzles      []Puzzler
+	answers      Answers
+	answersErr   error
+	examples     []Example
 }
 
 // Year represents a single year of AOC
@@ -34,6 +41,16 @@ func (y *Year) Day(number int) (Day, bool) {
 	return Day{}, false
 }
 
+// Days returns all of the Days in this Year, in the order they were provided
+func (y *Year) Days() []Day {
+	return y.days
+}
+
+// Number returns the calendar year, e.g. 2021
+func (y *Year) Number() int {
+	return y.number
+}
+
 func (y *Year) String() string {
 	return fmt.Sprintf("%d", y.number)
 }
@@ -42,6 +59,11 @@ func (d *Day) DefaultInput() string {
 	return d.defaultInput
 }
 
+// Number returns the day of the month, 1-25
+func (d *Day) Number() int {
+	return d.number
+}
+
 func (d *Day) Puzzles() []Puzzler {
 	return d.puzzles
 }
@@ -51,18 +73,65 @@ func (d *Day) String() string {
 }
 
 func NewDay(number int, defaultInput string, puzzles ...Puzzler) Day {
-	return Day{number, defaultInput, puzzles}
+	return Day{
+		number:       number,
+		defaultInput: defaultInput,
+		puzzles:      puzzles,
+	}
 }
 
 func NewYear(number int, days ...Day) Year {
 	return Year{number, days}
 }
 
-func Run(p Puzzler, input io.Reader) {
+// Solve calls p, converting any panic into an error so that a single broken
+// puzzle does not take down everything else that is running.
+//
+// If ctx is done before p returns, Solve returns ctx.Err() right away rather
+// than waiting on a puzzler that never checks for cancellation. Such a
+// puzzler keeps running in the background until it finishes on its own.
+func (p Puzzler) Solve(ctx context.Context, r io.Reader, l *Logger) (string, error) {
+	type result struct {
+		answer string
+		err    error
+	}
+
+	// buffered so an abandoned puzzler can still deliver its result and exit
+	ch := make(chan result, 1)
+
+	go func() {
+		defer func() {
+			if v := recover(); v != nil {
+				ch <- result{err: fmt.Errorf("panic: %v", v)}
+			}
+		}()
+
+		answer, err := p(ctx, r, l)
+		ch <- result{answer, err}
+	}()
+
+	select {
+	case res := <-ch:
+		return res.answer, res.err
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+}
+
+// Run solves p using input and prints the answer. If the puzzle fails, the
+// error is returned and nothing is printed.
+func Run(ctx context.Context, p Puzzler, input io.Reader) error {
+
+	l := NewLogger(os.Stderr, "", log.Default().Flags(), LevelInfo)
 
-	l := log.New(os.Stderr, "", log.Default().Flags())
+	result, stats, err := p.SolveWithStats(ctx, input, l)
+	if err != nil {
+		return err
+	}
 
-	result := p(input, l)
+	l.Printf("solved in %s", stats)
 
 	fmt.Println(result)
+
+	return nil
 }
@@ -0,0 +1,144 @@
+package aoc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSolveReturnsAnswer(t *testing.T) {
+	p := Puzzler(func(ctx context.Context, r io.Reader, l *Logger) (string, error) {
+		data, err := io.ReadAll(r)
+		return strings.ToUpper(string(data)), err
+	})
+
+	answer, err := p.Solve(context.Background(), strings.NewReader("abc"), DiscardLogger())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if answer != "ABC" {
+		t.Errorf("Expected ABC, got %s", answer)
+	}
+}
+
+func TestSolveReturnsError(t *testing.T) {
+	expected := errors.New("nope")
+	p := Puzzler(func(ctx context.Context, r io.Reader, l *Logger) (string, error) {
+		return "", expected
+	})
+
+	_, err := p.Solve(context.Background(), strings.NewReader(""), DiscardLogger())
+	if err != expected {
+		t.Errorf("Expected %v, got %v", expected, err)
+	}
+}
+
+func TestSolveRecoversFromPanic(t *testing.T) {
+	p := Puzzler(func(ctx context.Context, r io.Reader, l *Logger) (string, error) {
+		panic("something broke")
+	})
+
+	_, err := p.Solve(context.Background(), strings.NewReader(""), DiscardLogger())
+	if err == nil {
+		t.Fatal("Expected an error")
+	}
+	if err.Error() != "panic: something broke" {
+		t.Errorf("Wrong error: %v", err)
+	}
+}
+
+func TestSolveGivesUpWhenContextIsDone(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+
+	// This puzzler never checks ctx
+	p := Puzzler(func(ctx context.Context, r io.Reader, l *Logger) (string, error) {
+		<-release
+		return "too late", nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	answer, err := p.Solve(ctx, strings.NewReader(""), DiscardLogger())
+	if err != context.DeadlineExceeded {
+		t.Errorf("Expected %v, got %v (answer %q)", context.DeadlineExceeded, err, answer)
+	}
+}
+
+func TestSolveWithStats(t *testing.T) {
+	var kept [][]byte
+
+	p := Puzzler(func(ctx context.Context, r io.Reader, l *Logger) (string, error) {
+		for i := 0; i < 100; i++ {
+			kept = append(kept, make([]byte, 1024))
+		}
+		time.Sleep(time.Millisecond)
+		return "done", nil
+	})
+
+	answer, stats, err := p.SolveWithStats(context.Background(), strings.NewReader(""), DiscardLogger())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if answer != "done" {
+		t.Errorf("Expected done, got %s", answer)
+	}
+
+	if stats.Duration < time.Millisecond {
+		t.Errorf("Duration too short: %v", stats.Duration)
+	}
+
+	if stats.Allocs < 100 {
+		t.Errorf("Expected at least 100 allocs, got %d", stats.Allocs)
+	}
+
+	if stats.AllocBytes < 100*1024 {
+		t.Errorf("Expected at least 100 KiB allocated, got %d", stats.AllocBytes)
+	}
+
+	if stats.PeakHeap < 100*1024 {
+		t.Errorf("Expected a peak heap of at least 100 KiB, got %d", stats.PeakHeap)
+	}
+}
+
+func TestSolveWithStatsIgnoresEarlierGarbage(t *testing.T) {
+	// garbage left behind by something that ran earlier
+	garbage := make([]byte, 64*1024*1024)
+	garbage[len(garbage)-1] = 1
+	garbage = nil
+
+	p := Puzzler(func(ctx context.Context, r io.Reader, l *Logger) (string, error) {
+		return "done", nil
+	})
+
+	_, stats, err := p.SolveWithStats(context.Background(), strings.NewReader(""), DiscardLogger())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if stats.PeakHeap >= 16*1024*1024 {
+		t.Errorf("Expected the peak heap not to include earlier garbage, got %s", FormatBytes(stats.PeakHeap))
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	tests := map[uint64]string{
+		0:               "0 B",
+		1023:            "1023 B",
+		1024:            "1.0 KiB",
+		1536:            "1.5 KiB",
+		5 * 1024 * 1024: "5.0 MiB",
+		3 << 30:         "3.0 GiB",
+	}
+
+	for n, expected := range tests {
+		if actual := FormatBytes(n); actual != expected {
+			t.Errorf("%d: expected %s, got %s", n, expected, actual)
+		}
+	}
+}
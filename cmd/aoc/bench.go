@@ -0,0 +1,308 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/matthinz/aoc-golang"
+)
+
+// benchFormat lays out a row of aoc bench's table (see verifyFormat). The
+// time column is wide enough for durations such as "1m23.456789012s".
+const benchFormat = "%-4s  %3s  %4s  %8s  %14s  %12s  %10s  %s"
+
+// errRegressed is returned when a part got slower than --threshold allows
+// compared to a previous run
+var errRegressed = errors.New("one or more puzzles got slower")
+
+// benchResult records how a single part performed under aoc bench. Results
+// are saved to and compared against files as a JSON array.
+type benchResult struct {
+	Year        int   `json:"year"`
+	Day         int   `json:"day"`
+	Part        int   `json:"part"`
+	N           int   `json:"n"`
+	NsPerOp     int64 `json:"ns_per_op"`
+	BytesPerOp  int64 `json:"bytes_per_op"`
+	AllocsPerOp int64 `json:"allocs_per_op"`
+}
+
+// benchKey identifies a single part in a set of benchmark results
+type benchKey struct {
+	year int
+	day  int
+	part int
+}
+
+func benchCommand(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	var sel selection
+
+	fs := newFlagSet("bench", stderr)
+	sel.registerTargetFlags(fs)
+	sel.registerInputFlags(fs)
+	benchtime := fs.String("benchtime", "1s", "run each part for `t`, either a duration (e.g. 5s) or a number of iterations (e.g. 10x)")
+	timeout := registerTimeoutFlag(fs)
+	save := fs.String("save", "", "write the results to `file` as JSON")
+	compare := fs.String("compare", "", "compare the results against those previously saved to `file`")
+	threshold := fs.Float64("threshold", 10, "with --compare, fail parts that are more than `percent` slower")
+	profile := profiler{wrote: reportWrote("bench", stderr)}
+	profile.registerFlags(fs)
+
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	if err := setBenchtime(*benchtime); err != nil {
+		return err
+	}
+
+	if *threshold < 0 {
+		return usageErrorf("--threshold cannot be negative")
+	}
+
+	targets, err := sel.targets()
+	if err != nil {
+		return err
+	}
+
+	inputs, err := sel.inputProvider()
+	if err != nil {
+		return err
+	}
+
+	var previous map[benchKey]benchResult
+	if *compare != "" {
+		previous, err = readBenchFile(*compare)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Logging would only skew the timings
+	l := aoc.DiscardLogger()
+	failed := false
+	regressed := false
+	results := []benchResult{}
+
+	printBenchRow(stdout, "YEAR", "DAY", "PART", "N", "TIME/OP", "BYTES/OP", "ALLOCS/OP", "CHANGE")
+
+	for _, t := range targets {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		input, err := inputs.Input(ctx, t.year, t.day.Number())
+		if err != nil {
+			fmt.Fprintf(stderr, "aoc bench: %s: reading input: %s\n", t.String(), err)
+			failed = true
+			continue
+		}
+
+		// Profiles cover every iteration, which gives pprof more to work with
+		stop, err := profile.start(t)
+		if err != nil {
+			return fmt.Errorf("starting profile: %w", err)
+		}
+
+		r, err := t.bench(ctx, input, l, *timeout)
+
+		if stopErr := stop(); stopErr != nil {
+			return fmt.Errorf("writing profile: %w", stopErr)
+		}
+
+		if err != nil {
+			fmt.Fprintf(stderr, "aoc bench: %s: %s\n", t.String(), err)
+			failed = true
+			continue
+		}
+
+		results = append(results, r)
+
+		change := ""
+		if old, found := previous[r.key()]; found {
+			var slower bool
+			change, slower = benchChange(old, r, *threshold)
+			regressed = regressed || slower
+		}
+
+		printBenchRow(
+			stdout,
+			strconv.Itoa(r.Year),
+			strconv.Itoa(r.Day),
+			strconv.Itoa(r.Part),
+			strconv.Itoa(r.N),
+			time.Duration(r.NsPerOp).String(),
+			strconv.FormatInt(r.BytesPerOp, 10),
+			strconv.FormatInt(r.AllocsPerOp, 10),
+			change,
+		)
+	}
+
+	if *save != "" {
+		if err := writeBenchFile(*save, results); err != nil {
+			return err
+		}
+	}
+
+	switch {
+	case failed:
+		return errPuzzlesFailed
+	case regressed:
+		return errRegressed
+	}
+
+	return nil
+}
+
+// bench runs t against input repeatedly using testing.Benchmark, for as long
+// as --benchtime asks. t is solved once first, as aoc run would, so that a
+// part that fails, panics or runs past timeout is reported rather than
+// benchmarked.
+//
+// The iterations call t's puzzler directly, so that what is measured is the
+// puzzle rather than the stats and panic handling around it. Each round of
+// b.N iterations is allowed b.N times timeout.
+func (t *target) bench(ctx context.Context, input string, l *aoc.Logger, timeout time.Duration) (benchResult, error) {
+	if _, _, err := t.solve(ctx, input, l, timeout); err != nil {
+		return benchResult{}, err
+	}
+
+	puzzler := t.puzzler()
+	var err error
+
+	r := testing.Benchmark(func(b *testing.B) {
+		roundCtx := ctx
+		if limit := roundTimeout(timeout, b.N); limit > 0 {
+			var cancel context.CancelFunc
+			roundCtx, cancel = context.WithTimeout(ctx, limit)
+			defer cancel()
+		}
+
+		reader := strings.NewReader(input)
+
+		b.ReportAllocs()
+		b.ResetTimer()
+
+		for i := 0; i < b.N; i++ {
+			reader.Reset(input)
+			if _, err = puzzler(roundCtx, reader, l); err != nil {
+				b.FailNow()
+			}
+		}
+	})
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		return benchResult{}, &timeoutError{timeout}
+	}
+
+	if err != nil {
+		return benchResult{}, err
+	}
+
+	if r.N == 0 {
+		return benchResult{}, errors.New("benchmark did not run")
+	}
+
+	return benchResult{
+		Year:        t.year,
+		Day:         t.day.Number(),
+		Part:        t.part,
+		N:           r.N,
+		NsPerOp:     r.NsPerOp(),
+		BytesPerOp:  r.AllocedBytesPerOp(),
+		AllocsPerOp: r.AllocsPerOp(),
+	}, nil
+}
+
+// roundTimeout returns how long a round of n iterations may take when each
+// may take timeout, or 0 for no limit, including when the limit is too long
+// to represent
+func roundTimeout(timeout time.Duration, n int) time.Duration {
+	if timeout <= 0 || n <= 0 {
+		return 0
+	}
+
+	limit := timeout * time.Duration(n)
+	if limit/time.Duration(n) != timeout {
+		return 0
+	}
+
+	return limit
+}
+
+// setBenchtime passes value on to the testing package, which reads it from
+// its -test.benchtime flag
+func setBenchtime(value string) error {
+	testing.Init()
+	if err := flag.Set("test.benchtime", value); err != nil {
+		return usageErrorf("invalid --benchtime %q", value)
+	}
+	return nil
+}
+
+// benchChange describes how current's time per iteration compares with
+// old's, e.g. "+12.5%". The second value is true if current is more than
+// threshold percent slower.
+func benchChange(old, current benchResult, threshold float64) (string, bool) {
+	if old.NsPerOp <= 0 {
+		return "", false
+	}
+
+	percent := float64(current.NsPerOp-old.NsPerOp) / float64(old.NsPerOp) * 100
+	change := fmt.Sprintf("%+.1f%%", percent)
+
+	if percent > threshold {
+		return change + " REGRESSION", true
+	}
+
+	return change, false
+}
+
+func printBenchRow(w io.Writer, year, day, part, n, timePerOp, bytesPerOp, allocsPerOp, change string) {
+	row := fmt.Sprintf(benchFormat, year, day, part, n, timePerOp, bytesPerOp, allocsPerOp, change)
+	fmt.Fprintln(w, strings.TrimRight(row, " "))
+}
+
+func (r benchResult) key() benchKey {
+	return benchKey{r.Year, r.Day, r.Part}
+}
+
+// writeBenchFile saves results to the file at name as a JSON array
+func writeBenchFile(name string, results []benchResult) error {
+	data, err := json.MarshalIndent(results, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(name, append(data, '\n'), 0644)
+}
+
+// readBenchFile loads results previously saved by writeBenchFile, keyed by
+// part
+func readBenchFile(name string) (map[benchKey]benchResult, error) {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []benchResult
+	if err := json.Unmarshal(data, &results); err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+
+	byKey := make(map[benchKey]benchResult, len(results))
+	for _, r := range results {
+		byKey[r.key()] = r
+	}
+
+	return byKey, nil
+}
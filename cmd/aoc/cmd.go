@@ -1,106 +1,195 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"flag"
 	"fmt"
 	"io"
 	"os"
-	"strconv"
+	"os/signal"
 	"strings"
 
-	"github.com/matthinz/aoc-golang"
-	y2020 "github.com/matthinz/aoc-golang/2020"
-	y2021 "github.com/matthinz/aoc-golang/2021"
+	// Each year's package registers its puzzles with the aoc package
+	_ "github.com/matthinz/aoc-golang/2020"
+	_ "github.com/matthinz/aoc-golang/2021"
 )
 
 const FirstYear = 2015
 const MaxDays = 25
 
-var AllYears = map[int]func() aoc.Year{
-	2020: y2020.New,
-	2021: y2021.New,
+// Exit codes returned by the aoc binary
+const (
+	exitOK      = 0
+	exitFailure = 1
+	exitUsage   = 2
+)
+
+// command is a single aoc subcommand, e.g. "run" or "list"
+type command struct {
+	name    string
+	summary string
+	run     func(ctx context.Context, args []string, stdout, stderr io.Writer) error
 }
 
-func main() {
+// usageError is returned by commands when they were invoked incorrectly.
+type usageError struct {
+	message string
+}
 
-	yearNumbers, dayNumbers, err := parseArgs(os.Args[1:])
-	if err != nil {
-		panic(err)
-	}
+// errUsageReported is returned when a usage problem has already been printed
+// (e.g. by the flag package) and only the exit code remains to be set.
+var errUsageReported = errors.New("usage error")
 
-	for _, yearNumber := range yearNumbers {
-		factory, found := AllYears[yearNumber]
-		if !found {
-			panic(fmt.Sprintf("Invalid year: %d", yearNumber))
-		}
-		year := factory()
+// errPuzzlesFailed is returned when one or more parts did not produce an
+// answer. The individual failures are reported as they happen.
+var errPuzzlesFailed = errors.New("one or more puzzles failed")
 
-		for _, dayNumber := range dayNumbers {
-			day, found := year.Day(dayNumber)
-			if !found {
-				panic(fmt.Sprintf("Year %d, day %d not found", yearNumber, dayNumber))
-			}
+var commands []command
 
-			runDay(&day)
-		}
+func init() {
+	commands = []command{
+		{"run", "run puzzles and print their answers", runCommand},
+		{"list", "list the available puzzles", listCommand},
+		{"bench", "benchmark puzzles and compare against a previous run", benchCommand},
+		{"verify", "check puzzles' answers against the recorded ones", verifyCommand},
+		{"new", "generate the skeleton of a new day's package", newCommand},
+		{"help", "show help for a command", helpCommand},
 	}
 }
 
-func parseArgs(args []string) ([]int, []int, error) {
+func main() {
+	// Ctrl-C cancels whatever is running rather than killing the process, so
+	// that results so far still get reported
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	code := execute(ctx, os.Args[1:], os.Stdout, os.Stderr)
 
-	var years []int
-	var days []int
+	stop()
+	os.Exit(code)
+}
 
-	for _, arg := range args {
-		num, err := strconv.ParseInt(arg, 10, 16)
-		if err != nil {
-			return years, days, fmt.Errorf("Invalid argument: %s", arg)
-		}
+// execute runs the command described by args and returns the process exit code
+func execute(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		printUsage(stderr)
+		return exitUsage
+	}
 
-		if num < 1 {
-			return years, days, fmt.Errorf("Invalid argument: %s", arg)
-		}
+	switch args[0] {
+	case "-h", "-help", "--help":
+		printUsage(stdout)
+		return exitOK
+	}
 
-		if num >= FirstYear {
-			years = append(years, int(num))
-		} else if num <= MaxDays {
-			days = append(days, int(num))
-		}
+	cmd, found := findCommand(args[0])
+	if !found {
+		fmt.Fprintf(stderr, "aoc: unknown command %q\n\n", args[0])
+		printUsage(stderr)
+		return exitUsage
 	}
 
-	if len(years) == 0 {
-		for y := range AllYears {
-			years = append(years, y)
-		}
+	err := cmd.run(ctx, args[1:], stdout, stderr)
+
+	var usageErr *usageError
+
+	switch {
+	case err == nil, errors.Is(err, flag.ErrHelp):
+		return exitOK
+	case errors.Is(err, errUsageReported):
+		return exitUsage
+	case errors.As(err, &usageErr):
+		fmt.Fprintf(stderr, "aoc %s: %s\n", cmd.name, usageErr.message)
+		fmt.Fprintf(stderr, "Run 'aoc help %s' for usage.\n", cmd.name)
+		return exitUsage
+	default:
+		fmt.Fprintf(stderr, "aoc %s: %s\n", cmd.name, err)
+		return exitFailure
 	}
+}
 
-	if len(days) == 0 {
-		for d := 1; d <= MaxDays; d++ {
-			days = append(days, d)
+func findCommand(name string) (command, bool) {
+	for _, c := range commands {
+		if c.name == name {
+			return c, true
 		}
 	}
+	return command{}, false
+}
+
+func helpCommand(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	if len(args) == 0 {
+		printUsage(stdout)
+		return nil
+	}
+
+	if len(args) > 1 {
+		return usageErrorf("too many arguments")
+	}
+
+	cmd, found := findCommand(args[0])
+	if !found {
+		return usageErrorf("unknown command %q", args[0])
+	}
+
+	if cmd.name == "help" {
+		fmt.Fprintln(stdout, "usage: aoc help [command]")
+		return nil
+	}
 
-	return years, days, nil
+	// Every command prints its usage to stderr when given -h
+	return cmd.run(ctx, []string{"-h"}, stdout, stdout)
 }
 
-func runDay(day *aoc.Day) {
-	var input io.ReadSeeker
+func printUsage(w io.Writer) {
+	fmt.Fprintln(w, "usage: aoc <command> [flags]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+	for _, c := range commands {
+		fmt.Fprintf(w, "  %-8s %s\n", c.name, c.summary)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Run 'aoc help <command>' for details about a command's flags.")
+}
 
-	stat, err := os.Stdin.Stat()
-	if err == nil {
-		isTTY := (stat.Mode() & os.ModeCharDevice) != 0
-		if isTTY {
-			// no file, use the default
-			input = strings.NewReader(day.DefaultInput())
+// newFlagSet returns a FlagSet for the given command that reports problems
+// to w rather than exiting the process.
+func newFlagSet(name string, w io.Writer) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(w)
+	fs.Usage = func() {
+		cmd, _ := findCommand(name)
+		fmt.Fprintf(w, "usage: aoc %s [flags]\n\n", name)
+		if cmd.summary != "" {
+			fmt.Fprintf(w, "%s%s.\n\n", strings.ToUpper(cmd.summary[:1]), cmd.summary[1:])
 		}
+		fmt.Fprintln(w, "Flags:")
+		fs.PrintDefaults()
 	}
+	return fs
+}
 
-	if input == nil {
-		input = os.Stdin
+// parseFlags parses args into fs, rejecting any positional arguments
+func parseFlags(fs *flag.FlagSet, args []string) error {
+	err := fs.Parse(args)
+	if errors.Is(err, flag.ErrHelp) {
+		return err
 	}
-
-	for _, p := range day.Puzzles() {
-		input.Seek(0, io.SeekStart)
-		aoc.Run(p, input)
+	if err != nil {
+		// The flag package has already printed the problem and our usage
+		return errUsageReported
 	}
+	if fs.NArg() > 0 {
+		return usageErrorf("unexpected argument %q", fs.Arg(0))
+	}
+	return nil
+}
+
+func usageErrorf(format string, args ...interface{}) error {
+	return &usageError{fmt.Sprintf(format, args...)}
+}
 
+func (e *usageError) Error() string {
+	return e.message
 }
@@ -0,0 +1,946 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image/gif"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/matthinz/aoc-golang"
+)
+
+func TestIntListSet(t *testing.T) {
+	tests := map[string]string{
+		"5":         "5",
+		"1,3":       "1,3",
+		"1-3":       "1,2,3",
+		"7,1-3,2":   "1,2,3,7",
+		" 4 , 2-2 ": "2,4",
+	}
+
+	for input, expected := range tests {
+		t.Run(input, func(t *testing.T) {
+			var l intList
+			if err := l.Set(input); err != nil {
+				t.Fatal(err)
+			}
+			if actual := l.String(); actual != expected {
+				t.Errorf("Expected %s, got %s", expected, actual)
+			}
+		})
+	}
+}
+
+func TestIntListSetInvalid(t *testing.T) {
+	for _, input := range []string{"", "x", "3-1", "1-", "1,,2"} {
+		t.Run(input, func(t *testing.T) {
+			var l intList
+			if err := l.Set(input); err == nil {
+				t.Errorf("Expected an error for %q, got %v", input, l)
+			}
+		})
+	}
+}
+
+func TestSelectionTargets(t *testing.T) {
+	var sel selection
+	sel.years.Set("2021")
+	sel.days.Set("2-3")
+	sel.parts.Set("2")
+
+	targets, err := sel.targets()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{"2021 day 2 part 2", "2021 day 3 part 2"}
+
+	if len(targets) != len(expected) {
+		t.Fatalf("Expected %d targets, got %d", len(expected), len(targets))
+	}
+
+	for i := range expected {
+		if actual := targets[i].String(); actual != expected[i] {
+			t.Errorf("%d: expected %s, got %s", i, expected[i], actual)
+		}
+	}
+}
+
+func TestExecuteExitCodes(t *testing.T) {
+	tests := []struct {
+		args     string
+		expected int
+	}{
+		{"", exitUsage},
+		{"--help", exitOK},
+		{"bogus", exitUsage},
+		{"help run", exitOK},
+		{"run --bogus", exitUsage},
+		{"run 2021 5", exitUsage},
+		{"run --year 1999", exitUsage},
+		{"run --year 2020 --day 25", exitUsage},
+		{"run --day 1 --input somefile", exitUsage},
+		{"list --year 2020", exitOK},
+		{"run --jobs 0", exitUsage},
+		{"run --format xml", exitUsage},
+		{"new --day 1", exitUsage},
+		{"run --input a --input-dir b", exitUsage},
+		{"run --fetch", exitUsage},
+		{"run --year 2020 --day 1 --input does-not-exist", exitFailure},
+	}
+
+	for _, test := range tests {
+		t.Run(test.args, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			actual := execute(context.Background(), strings.Fields(test.args), &stdout, &stderr)
+			if actual != test.expected {
+				t.Errorf("Expected exit code %d, got %d\n%s", test.expected, actual, stderr.String())
+			}
+		})
+	}
+}
+
+func TestRunCommandOutput(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+
+	code := execute(context.Background(), []string{"run", "--year", "2020", "--day", "1"}, &stdout, &stderr)
+	if code != exitOK {
+		t.Fatalf("Expected exit code %d, got %d\n%s", exitOK, code, stderr.String())
+	}
+
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 lines of output, got %d: %q", len(lines), stdout.String())
+	}
+
+	for i, line := range lines {
+		fields := strings.Split(line, "\t")
+		if len(fields) != 4 {
+			t.Errorf("line %d: expected 4 fields, got %q", i, line)
+			continue
+		}
+		if fields[0] != "2020" || fields[1] != "1" {
+			t.Errorf("line %d: wrong year / day: %q", i, line)
+		}
+	}
+}
+
+func TestRunCommandJobsKeepsOrder(t *testing.T) {
+	args := []string{"run", "--year", "2020,2021", "--day", "1-4,6"}
+
+	var expected, stderr bytes.Buffer
+	if code := execute(context.Background(), args, &expected, &stderr); code != exitOK {
+		t.Fatalf("Expected exit code %d, got %d\n%s", exitOK, code, stderr.String())
+	}
+
+	var actual bytes.Buffer
+	if code := execute(context.Background(), append(args, "--jobs", "4"), &actual, &stderr); code != exitOK {
+		t.Fatalf("Expected exit code %d, got %d\n%s", exitOK, code, stderr.String())
+	}
+
+	if actual.String() != expected.String() {
+		t.Errorf("Output with --jobs 4 differs from sequential output:\n%s\nvs\n%s", actual.String(), expected.String())
+	}
+}
+
+func TestRunCommandStatsFile(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "stats.json")
+
+	var stdout, stderr bytes.Buffer
+
+	code := execute(context.Background(), []string{"run", "--year", "2020", "--day", "1", "--stats", "--stats-file", file}, &stdout, &stderr)
+	if code != exitOK {
+		t.Fatalf("Expected exit code %d, got %d\n%s", exitOK, code, stderr.String())
+	}
+
+	if !strings.Contains(stderr.String(), "PEAK HEAP") {
+		t.Errorf("Stats table was not printed: %q", stderr.String())
+	}
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var stats []partStats
+	if err := json.Unmarshal(data, &stats); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(stats) != 2 {
+		t.Fatalf("Expected stats for 2 parts, got %d", len(stats))
+	}
+
+	for i, s := range stats {
+		if s.Year != 2020 || s.Day != 1 || s.Part != i+1 {
+			t.Errorf("%d: wrong part: %+v", i, s)
+		}
+		if s.Duration <= 0 {
+			t.Errorf("%d: no duration recorded", i)
+		}
+	}
+}
+
+func TestRunCommandKeepsGoingAfterFailure(t *testing.T) {
+	// Part 1 needs at least one fold instruction, part 2 does not
+	input := filepath.Join(t.TempDir(), "input")
+	if err := os.WriteFile(input, []byte("1,1\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout, stderr bytes.Buffer
+
+	code := execute(context.Background(), []string{"run", "--year", "2021", "--day", "13", "--input", input}, &stdout, &stderr)
+	if code != exitFailure {
+		t.Fatalf("Expected exit code %d, got %d", exitFailure, code)
+	}
+
+	if !strings.Contains(stderr.String(), "2021 day 13 part 1: no fold instructions found") {
+		t.Errorf("Part 1 failure was not reported: %q", stderr.String())
+	}
+
+	if !strings.HasPrefix(stdout.String(), "2021\t13\t2\t") {
+		t.Errorf("Part 2 should still have run: %q", stdout.String())
+	}
+}
+
+func TestRunCommandJSONFormat(t *testing.T) {
+	// Part 1 needs at least one fold instruction, part 2 does not
+	input := filepath.Join(t.TempDir(), "input")
+	if err := os.WriteFile(input, []byte("1,1\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout, stderr bytes.Buffer
+
+	code := execute(context.Background(), []string{"run", "--year", "2021", "--day", "13", "--input", input, "--format", "json"}, &stdout, &stderr)
+	if code != exitFailure {
+		t.Fatalf("Expected exit code %d, got %d", exitFailure, code)
+	}
+
+	var records []runRecord
+
+	dec := json.NewDecoder(&stdout)
+	for dec.More() {
+		var record runRecord
+		if err := dec.Decode(&record); err != nil {
+			t.Fatal(err)
+		}
+		records = append(records, record)
+	}
+
+	if len(records) != 2 {
+		t.Fatalf("Expected 2 records, got %d", len(records))
+	}
+
+	if records[0].Part != 1 || records[0].Status != runStatusFailed || records[0].Error != "no fold instructions found" {
+		t.Errorf("Wrong record for part 1: %+v", records[0])
+	}
+
+	if records[1].Part != 2 || records[1].Status != runStatusSolved || records[1].Answer == "" {
+		t.Errorf("Wrong record for part 2: %+v", records[1])
+	}
+}
+
+func TestRunCommandInputDir(t *testing.T) {
+	dir := t.TempDir()
+
+	input := filepath.Join(dir, "2020", "01", "input")
+	if err := os.MkdirAll(filepath.Dir(input), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(input, []byte("1000\n1010\n10\n1010\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout, stderr bytes.Buffer
+
+	code := execute(context.Background(), []string{"run", "--year", "2020", "--input-dir", dir}, &stdout, &stderr)
+	if code != exitFailure {
+		t.Fatalf("Expected exit code %d, got %d", exitFailure, code)
+	}
+
+	expected := "2020\t1\t1\t1020100\n2020\t1\t2\t10100000\n"
+	if stdout.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, stdout.String())
+	}
+
+	// There's no input for day 2
+	if !strings.Contains(stderr.String(), "2020 day 2 part 1: reading input:") {
+		t.Errorf("Missing input was not reported: %q", stderr.String())
+	}
+}
+
+func TestRunCommandTimeout(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+
+	code := execute(context.Background(), []string{"run", "--year", "2021", "--day", "23", "--part", "2", "--timeout", "1ms"}, &stdout, &stderr)
+	if code != exitFailure {
+		t.Fatalf("Expected exit code %d, got %d", exitFailure, code)
+	}
+
+	if !strings.Contains(stderr.String(), "2021 day 23 part 2: timed out after 1ms") {
+		t.Errorf("Timeout was not reported: %q", stderr.String())
+	}
+}
+
+func TestVerifyStatus(t *testing.T) {
+	expected := aoc.Answers{1: "42"}
+
+	tests := []struct {
+		name   string
+		part   int
+		answer string
+		err    error
+		status string
+	}{
+		{"pass", 1, "42", nil, statusPass},
+		{"wrong answer", 1, "43", nil, statusFail},
+		{"error", 1, "", errors.New("broken"), statusFail},
+		{"timeout", 1, "", &timeoutError{time.Second}, statusTimeout},
+		{"missing", 2, "7", nil, statusMissing},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			status, _ := verifyStatus(expected, test.part, test.answer, test.err)
+			if status != test.status {
+				t.Errorf("Expected %s, got %s", test.status, status)
+			}
+		})
+	}
+}
+
+func TestVerifyCommandOutput(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+
+	code := execute(context.Background(), []string{"verify", "--year", "2020", "--day", "1"}, &stdout, &stderr)
+	if code != exitOK {
+		t.Fatalf("Expected exit code %d, got %d\n%s", exitOK, code, stdout.String())
+	}
+
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	if len(lines) != 5 {
+		t.Fatalf("Expected 5 lines of output, got %d: %q", len(lines), stdout.String())
+	}
+
+	for _, line := range lines[1:3] {
+		if fields := strings.Fields(line); len(fields) < 4 || fields[3] != statusPass {
+			t.Errorf("Expected part to pass: %q", line)
+		}
+	}
+
+	if lines[4] != "2 passed, 0 failed, 0 timed out, 0 missing" {
+		t.Errorf("Wrong summary: %q", lines[4])
+	}
+}
+
+func TestVerifyCommandRecord(t *testing.T) {
+	dir := t.TempDir()
+	dayDir := filepath.Join(dir, "2020", "01")
+
+	if err := os.MkdirAll(dayDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dayDir, "input"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout, stderr bytes.Buffer
+
+	code := execute(context.Background(), []string{"verify", "--year", "2020", "--day", "1", "--record", "--dir", dir}, &stdout, &stderr)
+	if code != exitOK {
+		t.Fatalf("Expected exit code %d, got %d\n%s", exitOK, code, stderr.String())
+	}
+
+	data, err := os.ReadFile(filepath.Join(dayDir, "answers.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	answers, err := aoc.ParseAnswers(string(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(answers) != 2 {
+		t.Errorf("Expected 2 answers to be recorded, got %v", answers)
+	}
+}
+
+func TestBenchChange(t *testing.T) {
+	old := benchResult{NsPerOp: 1000}
+
+	tests := []struct {
+		name      string
+		nsPerOp   int64
+		change    string
+		regressed bool
+	}{
+		{"faster", 500, "-50.0%", false},
+		{"same", 1000, "+0.0%", false},
+		{"within threshold", 1100, "+10.0%", false},
+		{"slower", 1200, "+20.0% REGRESSION", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			change, regressed := benchChange(old, benchResult{NsPerOp: test.nsPerOp}, 10)
+			if change != test.change {
+				t.Errorf("Expected %q, got %q", test.change, change)
+			}
+			if regressed != test.regressed {
+				t.Errorf("Expected regressed to be %v, got %v", test.regressed, regressed)
+			}
+		})
+	}
+}
+
+func TestRoundTimeout(t *testing.T) {
+	tests := []struct {
+		timeout  time.Duration
+		n        int
+		expected time.Duration
+	}{
+		{0, 100, 0},
+		{time.Second, 1, time.Second},
+		{time.Second, 100, 100 * time.Second},
+		// far too long to represent, so no limit at all
+		{time.Hour, 1000000000, 0},
+	}
+
+	for _, test := range tests {
+		if actual := roundTimeout(test.timeout, test.n); actual != test.expected {
+			t.Errorf("%v x %d: expected %v, got %v", test.timeout, test.n, test.expected, actual)
+		}
+	}
+}
+
+func TestBenchCommandTimeout(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+
+	code := execute(context.Background(), []string{
+		"bench", "--year", "2021", "--day", "22", "--part", "2", "--timeout", "1ms",
+	}, &stdout, &stderr)
+	if code != exitFailure {
+		t.Fatalf("Expected exit code %d, got %d\n%s", exitFailure, code, stderr.String())
+	}
+
+	if !strings.Contains(stderr.String(), "2021 day 22 part 2: timed out after 1ms") {
+		t.Errorf("Expected the part to time out: %q", stderr.String())
+	}
+}
+
+func TestBenchCommandSaveAndCompare(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "bench.json")
+	args := []string{"bench", "--year", "2021", "--day", "6", "--benchtime", "2x"}
+
+	var stdout, stderr bytes.Buffer
+
+	code := execute(context.Background(), append(args, "--save", file), &stdout, &stderr)
+	if code != exitOK {
+		t.Fatalf("Expected exit code %d, got %d\n%s", exitOK, code, stderr.String())
+	}
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var results []benchResult
+	if err := json.Unmarshal(data, &results); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(results) != 2 {
+		t.Fatalf("Expected results for 2 parts, got %d", len(results))
+	}
+
+	for i, r := range results {
+		if r.Year != 2021 || r.Day != 6 || r.Part != i+1 {
+			t.Errorf("%d: wrong part: %+v", i, r)
+		}
+		if r.N != 2 {
+			t.Errorf("%d: expected 2 iterations, got %d", i, r.N)
+		}
+		if r.NsPerOp <= 0 {
+			t.Errorf("%d: no time recorded", i)
+		}
+	}
+
+	// Pretend the last run was impossibly fast, so that this one regresses
+	for i := range results {
+		results[i].NsPerOp = 1
+	}
+	if err := writeBenchFile(file, results); err != nil {
+		t.Fatal(err)
+	}
+
+	stdout.Reset()
+	stderr.Reset()
+
+	code = execute(context.Background(), append(args, "--compare", file), &stdout, &stderr)
+	if code != exitFailure {
+		t.Fatalf("Expected exit code %d, got %d\n%s", exitFailure, code, stderr.String())
+	}
+
+	if n := strings.Count(stdout.String(), "REGRESSION"); n != 2 {
+		t.Errorf("Expected 2 regressions to be flagged, got %d:\n%s", n, stdout.String())
+	}
+}
+
+func TestTargetFileName(t *testing.T) {
+	part := target{2021, aoc.NewDay(6, ""), 2}
+
+	tests := map[string]string{
+		"cpu.pprof":     "cpu-2021-06-part2.pprof",
+		"out/trace.out": filepath.Join("out", "trace-2021-06-part2.out"),
+		"mem":           "mem-2021-06-part2",
+	}
+
+	for name, expected := range tests {
+		if actual := part.fileName(name); actual != expected {
+			t.Errorf("%s: expected %s, got %s", name, expected, actual)
+		}
+	}
+}
+
+func TestRunCommandProfiles(t *testing.T) {
+	dir := t.TempDir()
+
+	var stdout, stderr bytes.Buffer
+
+	code := execute(context.Background(), []string{
+		"run", "--year", "2021", "--day", "6",
+		"--cpuprofile", filepath.Join(dir, "cpu.pprof"),
+		"--memprofile", filepath.Join(dir, "mem.pprof"),
+		"--trace", filepath.Join(dir, "trace.out"),
+	}, &stdout, &stderr)
+	if code != exitOK {
+		t.Fatalf("Expected exit code %d, got %d\n%s", exitOK, code, stderr.String())
+	}
+
+	for _, name := range []string{
+		"cpu-2021-06-part1.pprof",
+		"cpu-2021-06-part2.pprof",
+		"mem-2021-06-part1.pprof",
+		"mem-2021-06-part2.pprof",
+		"trace-2021-06-part1.out",
+		"trace-2021-06-part2.out",
+	} {
+		info, err := os.Stat(filepath.Join(dir, name))
+		if err != nil {
+			t.Errorf("%s was not written: %v", name, err)
+			continue
+		}
+		if info.Size() == 0 {
+			t.Errorf("%s is empty", name)
+		}
+		if !strings.Contains(stderr.String(), name) {
+			t.Errorf("%s was not reported", name)
+		}
+	}
+}
+
+func TestRunCommandProfilesNeedOneJob(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+
+	code := execute(context.Background(), []string{"run", "--jobs", "2", "--cpuprofile", filepath.Join(t.TempDir(), "cpu.pprof")}, &stdout, &stderr)
+	if code != exitUsage {
+		t.Errorf("Expected exit code %d, got %d", exitUsage, code)
+	}
+}
+
+func TestProgressReporterLogs(t *testing.T) {
+	var logs bytes.Buffer
+	l := aoc.NewLogger(&logs, "", 0, aoc.LevelInfo)
+
+	r := &progressReporter{interval: time.Millisecond}
+	ctx, stop := r.watch(context.Background(), target{2021, aoc.NewDay(22, ""), 2}, l)
+
+	progress := aoc.ProgressFrom(ctx)
+	progress.SetTotal(100)
+	progress.Add(40)
+
+	time.Sleep(20 * time.Millisecond)
+	stop()
+
+	if !strings.Contains(logs.String(), "progress: 40/100 (40.0%)") {
+		t.Errorf("Progress was not logged: %q", logs.String())
+	}
+}
+
+func TestProgressReporterLine(t *testing.T) {
+	var out bytes.Buffer
+
+	r := &progressReporter{w: &out, line: true, interval: time.Hour}
+
+	r.draw("2021 day 22 part 2: 1 done")
+	fmt.Fprintln(r, "a log line")
+	r.draw("2021 day 22 part 2: 2 done")
+	r.draw("")
+
+	expected := clearLine + "2021 day 22 part 2: 1 done" +
+		clearLine + "a log line\n" +
+		clearLine + "2021 day 22 part 2: 2 done" +
+		clearLine
+
+	if out.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, out.String())
+	}
+}
+
+func TestRunCommandLogLevels(t *testing.T) {
+	tests := []struct {
+		name     string
+		flags    []string
+		expected []string
+		hidden   []string
+	}{
+		{
+			"default",
+			nil,
+			[]string{"2021 day 13 part 1: fold along "},
+			[]string{"debug:"},
+		},
+		{
+			"verbose",
+			[]string{"-v"},
+			[]string{"2021 day 13 part 1: fold along ", "2021 day 2 part 1: debug: "},
+			nil,
+		},
+		{
+			"quiet",
+			[]string{"-q"},
+			nil,
+			[]string{"fold along", "debug:"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+
+			args := append([]string{"run", "--year", "2021", "--day", "2,13"}, test.flags...)
+
+			code := execute(context.Background(), args, &stdout, &stderr)
+			if code != exitOK {
+				t.Fatalf("Expected exit code %d, got %d\n%s", exitOK, code, stderr.String())
+			}
+
+			for _, s := range test.expected {
+				if !strings.Contains(stderr.String(), s) {
+					t.Errorf("Expected %q to be logged:\n%s", s, stderr.String())
+				}
+			}
+
+			for _, s := range test.hidden {
+				if strings.Contains(stderr.String(), s) {
+					t.Errorf("Expected %q not to be logged:\n%s", s, stderr.String())
+				}
+			}
+		})
+	}
+}
+
+func TestRunCommandLogFile(t *testing.T) {
+	dir := t.TempDir()
+
+	var stdout, stderr bytes.Buffer
+
+	code := execute(context.Background(), []string{"run", "--year", "2021", "--day", "13", "--log-file", filepath.Join(dir, "aoc.log")}, &stdout, &stderr)
+	if code != exitOK {
+		t.Fatalf("Expected exit code %d, got %d\n%s", exitOK, code, stderr.String())
+	}
+
+	if strings.Contains(stderr.String(), "fold along") {
+		t.Errorf("Log went to stderr as well as the file:\n%s", stderr.String())
+	}
+
+	for part := 1; part <= 2; part++ {
+		data, err := os.ReadFile(filepath.Join(dir, fmt.Sprintf("aoc-2021-13-part%d.log", part)))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !strings.Contains(string(data), fmt.Sprintf("2021 day 13 part %d: fold along", part)) {
+			t.Errorf("part %d: log is missing its messages: %q", part, data)
+		}
+	}
+}
+
+func TestRunCommandVerboseAndQuiet(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+
+	code := execute(context.Background(), []string{"run", "--year", "2021", "--day", "13", "-v", "-q"}, &stdout, &stderr)
+	if code != exitUsage {
+		t.Errorf("Expected exit code %d, got %d", exitUsage, code)
+	}
+}
+
+func TestRunCommandVisualizeGIF(t *testing.T) {
+	dir := t.TempDir()
+
+	var stdout, stderr bytes.Buffer
+
+	code := execute(context.Background(), []string{
+		"run", "--year", "2021", "--day", "11", "--part", "1",
+		"--visualize", filepath.Join(dir, "octopus.gif"),
+	}, &stdout, &stderr)
+	if code != exitOK {
+		t.Fatalf("Expected exit code %d, got %d\n%s", exitOK, code, stderr.String())
+	}
+
+	name := filepath.Join(dir, "octopus-2021-11-part1.gif")
+
+	f, err := os.Open(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	anim, err := gif.DecodeAll(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the starting grid plus one frame per step
+	if len(anim.Image) != 101 {
+		t.Errorf("Expected 101 frames, got %d", len(anim.Image))
+	}
+
+	if !strings.Contains(stderr.String(), "wrote "+name) {
+		t.Errorf("%s was not reported: %q", name, stderr.String())
+	}
+}
+
+func TestRunCommandVisualizeTerminal(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+
+	code := execute(context.Background(), []string{
+		"run", "--year", "2021", "--day", "13", "--part", "1",
+		"--visualize", "terminal", "--frame-delay", "1ms",
+	}, &stdout, &stderr)
+	if code != exitOK {
+		t.Fatalf("Expected exit code %d, got %d\n%s", exitOK, code, stderr.String())
+	}
+
+	out := stdout.String()
+
+	for _, expected := range []string{"unfolded\n", "fold along "} {
+		if !strings.Contains(out, expected) {
+			t.Errorf("Expected output to contain %q", expected)
+		}
+	}
+
+	// the animation is played before the answer is printed
+	answer := strings.Index(out, "2021\t13\t1\t")
+	if answer < strings.LastIndex(out, "fold along ") {
+		t.Errorf("Expected the answer after the animation, got %q", out)
+	}
+}
+
+func TestRunCommandVisualizeNothing(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+
+	code := execute(context.Background(), []string{
+		"run", "--year", "2021", "--day", "6", "--part", "1",
+		"--visualize", filepath.Join(t.TempDir(), "fish.gif"),
+	}, &stdout, &stderr)
+	if code != exitOK {
+		t.Fatalf("Expected exit code %d, got %d\n%s", exitOK, code, stderr.String())
+	}
+
+	if !strings.Contains(stderr.String(), "2021 day 6 part 1: nothing to visualize") {
+		t.Errorf("Expected to be told there was nothing to visualize: %q", stderr.String())
+	}
+}
+
+func TestRunCommandVisualizeUsage(t *testing.T) {
+	tests := [][]string{
+		{"--visualize", "out.bmp"},
+		{"--visualize", "terminal", "--jobs", "2"},
+		{"--visualize", "terminal", "--format", "json"},
+		{"--visualize", "out.gif", "--frame-delay", "-1s"},
+	}
+
+	for _, args := range tests {
+		var stdout, stderr bytes.Buffer
+
+		code := execute(context.Background(), append([]string{"run", "--year", "2021", "--day", "11"}, args...), &stdout, &stderr)
+		if code != exitUsage {
+			t.Errorf("%v: expected exit code %d, got %d", args, exitUsage, code)
+		}
+	}
+}
+
+func TestRunCommandExport(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "out")
+	var stdout, stderr bytes.Buffer
+
+	code := execute(context.Background(), []string{
+		"run", "--year", "2021", "--day", "2", "--part", "2", "--export", dir,
+	}, &stdout, &stderr)
+	if code != exitOK {
+		t.Fatalf("Expected exit code %d, got %d\n%s", exitOK, code, stderr.String())
+	}
+
+	for _, name := range []string{"trace-2021-02-part2.csv", "trace-2021-02-part2.json"} {
+		file := filepath.Join(dir, name)
+
+		data, err := os.ReadFile(file)
+		if err != nil {
+			t.Errorf("%s was not exported: %v", name, err)
+			continue
+		}
+		if len(data) == 0 {
+			t.Errorf("%s is empty", name)
+		}
+
+		if !strings.Contains(stderr.String(), "aoc run: wrote "+file+"\n") {
+			t.Errorf("Expected to be told about %s: %q", file, stderr.String())
+		}
+	}
+
+	if !strings.HasPrefix(stdout.String(), "2021\t2\t2\t") {
+		t.Errorf("Expected the answer as well: %q", stdout.String())
+	}
+}
+
+func TestRunCommandOptions(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+
+	code := execute(context.Background(), []string{
+		"run", "--year", "2021", "--day", "4", "--part", "1",
+		"--option", "rule=corners", "--option", "rig=3",
+	}, &stdout, &stderr)
+	if code != exitOK {
+		t.Fatalf("Expected exit code %d, got %d\n%s", exitOK, code, stderr.String())
+	}
+
+	if !strings.Contains(stderr.String(), "board 3 wins first if the numbers are drawn in this order: ") {
+		t.Errorf("Expected the options to reach the puzzle: %q", stderr.String())
+	}
+
+	stdout.Reset()
+	stderr.Reset()
+
+	code = execute(context.Background(), []string{"run", "--year", "2021", "--day", "4", "--option", "rule"}, &stdout, &stderr)
+	if code != exitUsage {
+		t.Errorf("Expected exit code %d for an option without a value, got %d", exitUsage, code)
+	}
+}
+
+func TestNewCommand(t *testing.T) {
+	dir := t.TempDir()
+
+	files := map[string]string{
+		"go.mod": "module example.com/aoc\n\ngo 1.17\n",
+		"2021/y2021.go": `package y2021
+
+import (
+	_ "example.com/aoc/2021/01"
+	_ "example.com/aoc/2021/03"
+)
+`,
+		"cmd/aoc/cmd.go": `package main
+
+import (
+	"fmt"
+
+	_ "example.com/aoc/2021"
+)
+`,
+		"examples_test.go": `package aoc_test
+
+import (
+	"testing"
+
+	// register every day so that their examples get checked
+	_ "example.com/aoc/2021"
+)
+`,
+	}
+
+	for name, contents := range files {
+		file := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(file, []byte(contents), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, args := range [][]string{
+		{"new", "--dir", dir, "--year", "2021", "--day", "2", "--name", "dive"},
+		{"new", "--dir", dir, "--year", "2022", "--day", "1"},
+	} {
+		var stdout, stderr bytes.Buffer
+		if code := execute(context.Background(), args, &stdout, &stderr); code != exitOK {
+			t.Fatalf("%v: expected exit code %d, got %d\n%s", args, exitOK, code, stderr.String())
+		}
+	}
+
+	for _, name := range []string{
+		"2021/02/dive.go",
+		"2021/02/input",
+		"2021/02/answers.json",
+		"2022/01/puzzle.go",
+		"2022/y2022.go",
+	} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Errorf("%s was not created: %v", name, err)
+		}
+	}
+
+	expected := map[string][]string{
+		"2021/y2021.go": {
+			`_ "example.com/aoc/2021/01"`,
+			`_ "example.com/aoc/2021/02"`,
+			`_ "example.com/aoc/2021/03"`,
+		},
+		"2022/y2022.go": {
+			`_ "example.com/aoc/2022/01"`,
+		},
+		"cmd/aoc/cmd.go": {
+			`_ "example.com/aoc/2021"`,
+			`_ "example.com/aoc/2022"`,
+		},
+		"examples_test.go": {
+			`_ "example.com/aoc/2021"`,
+			`_ "example.com/aoc/2022"`,
+		},
+	}
+
+	for name, imports := range expected {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Imports should be present and in order
+		pos := 0
+		for _, imp := range imports {
+			i := strings.Index(string(data[pos:]), imp)
+			if i < 0 {
+				t.Errorf("%s: %s missing or out of order:\n%s", name, imp, data)
+				break
+			}
+			pos += i
+		}
+	}
+
+	var stdout, stderr bytes.Buffer
+	code := execute(context.Background(), []string{"new", "--dir", dir, "--year", "2021", "--day", "2"}, &stdout, &stderr)
+	if code != exitFailure {
+		t.Errorf("Expected exit code %d when the day already exists, got %d", exitFailure, code)
+	}
+}
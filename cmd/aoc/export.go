@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/matthinz/aoc-golang"
+)
+
+// exporter gives each part somewhere to export files to (see aoc.Exporter).
+// Files go in a single directory, named after the one the part asked for with
+// the part added, e.g. a trace.csv from 2021 day 2 part 1 is written to
+// trace-2021-02-part1.csv.
+type exporter struct {
+	dir string
+
+	// called with the name of each file once it has been written
+	wrote func(name string)
+}
+
+// targetExporter is the aoc.Exporter given to a single part
+type targetExporter struct {
+	*exporter
+	target target
+}
+
+// exportFile is a file being exported, which reports itself as written once
+// it has been closed
+type exportFile struct {
+	*os.File
+	wrote func(name string)
+}
+
+// registerFlags adds the --export flag to fs
+func (e *exporter) registerFlags(fs *flag.FlagSet) {
+	fs.StringVar(&e.dir, "export", "", "write any files that parts export, e.g. traces, to `dir`, with the year, day and part added to their names")
+}
+
+// enabled returns whether exported files should be written
+func (e *exporter) enabled() bool {
+	return e != nil && e.dir != ""
+}
+
+// start returns a copy of ctx carrying an aoc.Exporter for t, if exporting
+func (e *exporter) start(ctx context.Context, t target) context.Context {
+	if !e.enabled() {
+		return ctx
+	}
+	return aoc.WithExporter(ctx, &targetExporter{e, t})
+}
+
+func (e *targetExporter) Create(name string) (io.WriteCloser, error) {
+	if err := os.MkdirAll(e.dir, 0755); err != nil {
+		return nil, err
+	}
+
+	// only the base of name is used, so that files stay inside e.dir
+	f, err := os.Create(e.target.fileName(filepath.Join(e.dir, filepath.Base(name))))
+	if err != nil {
+		return nil, err
+	}
+
+	return &exportFile{f, e.wrote}, nil
+}
+
+func (f *exportFile) Close() error {
+	if err := f.File.Close(); err != nil {
+		return err
+	}
+	f.wrote(f.Name())
+	return nil
+}
@@ -0,0 +1,378 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/matthinz/aoc-golang"
+)
+
+// sessionEnv names the environment variable holding the Advent of Code session
+// cookie used by --fetch
+const sessionEnv = "AOC_SESSION"
+
+// intList is a flag.Value that collects integers given as a comma-separated
+// list of numbers and ranges, e.g. "1-3,7". The flag may be repeated.
+type intList []int
+
+// optionFlag is a flag.Value that collects puzzle options given as
+// name=value. The flag may be repeated.
+type optionFlag aoc.Options
+
+// selection holds the flags shared by commands that operate on a set of
+// puzzles.
+type selection struct {
+	years intList
+	days  intList
+	parts intList
+
+	input    string
+	inputDir string
+	fetch    bool
+}
+
+// timeoutError is returned when a part runs longer than --timeout allows.
+type timeoutError struct {
+	timeout time.Duration
+}
+
+// dayKey identifies a single day's puzzle
+type dayKey struct {
+	year int
+	day  int
+}
+
+// target is a single part of a single day's puzzle.
+type target struct {
+	year int
+	day  aoc.Day
+	part int
+}
+
+func (l *intList) String() string {
+	if l == nil {
+		return ""
+	}
+	tokens := make([]string, len(*l))
+	for i, n := range *l {
+		tokens[i] = strconv.Itoa(n)
+	}
+	return strings.Join(tokens, ",")
+}
+
+func (l *intList) Set(value string) error {
+	for _, token := range strings.Split(value, ",") {
+		token = strings.TrimSpace(token)
+
+		bounds := strings.SplitN(token, "-", 2)
+
+		from, err := strconv.Atoi(bounds[0])
+		if err != nil {
+			return fmt.Errorf("invalid number %q", bounds[0])
+		}
+
+		to := from
+		if len(bounds) == 2 {
+			to, err = strconv.Atoi(bounds[1])
+			if err != nil {
+				return fmt.Errorf("invalid number %q", bounds[1])
+			}
+			if to < from {
+				return fmt.Errorf("invalid range %q", token)
+			}
+		}
+
+		for n := from; n <= to; n++ {
+			if !l.contains(n) {
+				*l = append(*l, n)
+			}
+		}
+	}
+
+	sort.Ints(*l)
+
+	return nil
+}
+
+func (l intList) contains(n int) bool {
+	for _, x := range l {
+		if x == n {
+			return true
+		}
+	}
+	return false
+}
+
+func (o optionFlag) String() string {
+	names := make([]string, 0, len(o))
+	for name := range o {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for i, name := range names {
+		names[i] = name + "=" + o[name]
+	}
+	return strings.Join(names, ",")
+}
+
+func (o optionFlag) Set(value string) error {
+	i := strings.Index(value, "=")
+	if i <= 0 {
+		return fmt.Errorf("expected name=value, got %q", value)
+	}
+	o[value[:i]] = value[i+1:]
+	return nil
+}
+
+// registerTargetFlags adds the --year, --day and --part flags to fs
+func (s *selection) registerTargetFlags(fs *flag.FlagSet) {
+	fs.Var(&s.years, "year", "`years` to include, e.g. 2021 or 2020,2021 (default all)")
+	fs.Var(&s.days, "day", "`days` to include, e.g. 5 or 1-3,7 (default all)")
+	fs.Var(&s.parts, "part", "`parts` to include, e.g. 1 or 2 (default all)")
+}
+
+// registerInputFlags adds the --input, --input-dir and --fetch flags to fs
+func (s *selection) registerInputFlags(fs *flag.FlagSet) {
+	fs.StringVar(&s.input, "input", "", "read puzzle input from `file` instead of the embedded input (\"-\" for stdin)")
+	fs.StringVar(&s.inputDir, "input-dir", "", "read each day's input from `dir`/<year>/<day>/input instead of the embedded input")
+	fs.BoolVar(&s.fetch, "fetch", false, "download inputs missing from --input-dir, using the session cookie in $"+sessionEnv)
+}
+
+// registerTimeoutFlag adds the --timeout flag to fs
+func registerTimeoutFlag(fs *flag.FlagSet) *time.Duration {
+	return fs.Duration("timeout", 0, "give up on each part after `duration`, e.g. 30s (default no limit)")
+}
+
+// registerOptionFlag adds the --option flag to fs
+func registerOptionFlag(fs *flag.FlagSet) aoc.Options {
+	options := aoc.Options{}
+	fs.Var(optionFlag(options), "option", "pass `name=value` to the puzzles as an option; see each day's documentation for the options it understands (may be repeated)")
+	return options
+}
+
+// registerJobsFlag adds the --jobs flag to fs
+func registerJobsFlag(fs *flag.FlagSet) *int {
+	return fs.Int("jobs", 1, "solve up to `n` parts at once")
+}
+
+// registerStatsFlags adds the --stats and --stats-file flags to fs
+func registerStatsFlags(fs *flag.FlagSet) (*bool, *string) {
+	table := fs.Bool("stats", false, "print the time and memory used by each part to stderr when done")
+	file := fs.String("stats-file", "", "write the time and memory used by each part to `file` as JSON")
+	return table, file
+}
+
+// logOptions holds the flags that control what puzzles log and where
+type logOptions struct {
+	verbose bool
+	quiet   bool
+	file    string
+}
+
+// register adds the -v, -q and --log-file flags to fs
+func (f *logOptions) register(fs *flag.FlagSet) {
+	fs.BoolVar(&f.verbose, "v", false, "include puzzles' debug messages in their logs")
+	fs.BoolVar(&f.quiet, "q", false, "only log puzzles' warnings")
+	fs.StringVar(&f.file, "log-file", "", "write each part's log to `file` instead of stderr, with the year, day and part added to the name")
+}
+
+// level returns the least important messages to log, according to -v and -q
+func (f *logOptions) level() (aoc.Level, error) {
+	switch {
+	case f.verbose && f.quiet:
+		return 0, usageErrorf("-v and -q cannot be used together")
+	case f.verbose:
+		return aoc.LevelDebug, nil
+	case f.quiet:
+		return aoc.LevelWarn, nil
+	default:
+		return aoc.LevelInfo, nil
+	}
+}
+
+// checkJobs validates the value given for --jobs
+func checkJobs(jobs int) error {
+	if jobs < 1 {
+		return usageErrorf("--jobs must be at least 1")
+	}
+	return nil
+}
+
+// targets resolves the selection into the list of matching puzzle parts,
+// ordered by year, day and part.
+func (s *selection) targets() ([]target, error) {
+	for _, y := range s.years {
+		if y < FirstYear {
+			return nil, usageErrorf("invalid year %d", y)
+		}
+	}
+
+	for _, d := range s.days {
+		if d < 1 || d > MaxDays {
+			return nil, usageErrorf("invalid day %d", d)
+		}
+	}
+
+	for _, p := range s.parts {
+		if p < 1 {
+			return nil, usageErrorf("invalid part %d", p)
+		}
+	}
+
+	var years []aoc.Year
+
+	if len(s.years) == 0 {
+		years = aoc.Years()
+	}
+
+	for _, yearNumber := range s.years {
+		year, found := aoc.LookupYear(yearNumber)
+		if !found {
+			return nil, usageErrorf("no puzzles available for year %d", yearNumber)
+		}
+		years = append(years, year)
+	}
+
+	var result []target
+
+	for _, year := range years {
+		for _, day := range year.Days() {
+			if len(s.days) > 0 && !s.days.contains(day.Number()) {
+				continue
+			}
+
+			for part := 1; part <= len(day.Puzzles()); part++ {
+				if len(s.parts) > 0 && !s.parts.contains(part) {
+					continue
+				}
+				result = append(result, target{year.Number(), day, part})
+			}
+		}
+	}
+
+	if len(result) == 0 {
+		return nil, usageErrorf("no puzzles match %s", s.describe())
+	}
+
+	if s.input != "" && spansMultipleDays(result) {
+		return nil, usageErrorf("--input can only be used when a single day is selected")
+	}
+
+	return result, nil
+}
+
+// inputProvider returns where puzzle input should come from, according to
+// --input, --input-dir and --fetch
+func (s *selection) inputProvider() (aoc.InputProvider, error) {
+	if s.input != "" && s.inputDir != "" {
+		return nil, usageErrorf("--input and --input-dir cannot be used together")
+	}
+
+	if s.fetch && s.inputDir == "" {
+		return nil, usageErrorf("--fetch requires --input-dir")
+	}
+
+	switch {
+	case s.input == "-":
+		return aoc.NewStdinInputProvider(), nil
+	case s.input != "":
+		return aoc.NewFileInputProvider(s.input), nil
+	case s.inputDir != "" && s.fetch:
+		session := os.Getenv(sessionEnv)
+		if session == "" {
+			return nil, usageErrorf("--fetch requires $%s to be set", sessionEnv)
+		}
+		fetcher := aoc.NewHTTPInputProvider(aoc.DefaultInputURL, session, nil)
+		return aoc.NewCacheInputProvider(s.inputDir, fetcher), nil
+	case s.inputDir != "":
+		return aoc.NewCacheInputProvider(s.inputDir, nil), nil
+	default:
+		return aoc.NewEmbeddedInputProvider(), nil
+	}
+}
+
+// describe summarizes the selection for use in error messages
+func (s *selection) describe() string {
+	var parts []string
+	if len(s.years) > 0 {
+		parts = append(parts, "--year "+s.years.String())
+	}
+	if len(s.days) > 0 {
+		parts = append(parts, "--day "+s.days.String())
+	}
+	if len(s.parts) > 0 {
+		parts = append(parts, "--part "+s.parts.String())
+	}
+	if len(parts) == 0 {
+		return "the selection"
+	}
+	return strings.Join(parts, " ")
+}
+
+func (t *target) puzzler() aoc.Puzzler {
+	return t.day.Puzzles()[t.part-1]
+}
+
+// solve runs t against input. If timeout is non-zero and the part runs longer
+// than that, a *timeoutError is returned.
+func (t *target) solve(ctx context.Context, input string, l *aoc.Logger, timeout time.Duration) (string, aoc.Stats, error) {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	answer, stats, err := t.puzzler().SolveWithStats(ctx, strings.NewReader(input), l)
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		return "", stats, &timeoutError{timeout}
+	}
+
+	return answer, stats, err
+}
+
+// fileName returns the name of a file holding something about t alone, based
+// on name, e.g. "out/cpu.pprof" becomes "out/cpu-2021-22-part2.pprof"
+func (t *target) fileName(name string) string {
+	dir, base := filepath.Split(name)
+	ext := filepath.Ext(base)
+	base = strings.TrimSuffix(base, ext)
+
+	return filepath.Join(dir, fmt.Sprintf("%s-%d-%02d-part%d%s", base, t.year, t.day.Number(), t.part, ext))
+}
+
+func (t *target) key() dayKey {
+	return dayKey{t.year, t.day.Number()}
+}
+
+func (t *target) String() string {
+	return fmt.Sprintf("%d day %d part %d", t.year, t.day.Number(), t.part)
+}
+
+func (e *timeoutError) Error() string {
+	return fmt.Sprintf("timed out after %v", e.timeout)
+}
+
+// isTimeout returns whether err indicates that a part ran out of time
+func isTimeout(err error) bool {
+	var timeoutErr *timeoutError
+	return errors.As(err, &timeoutErr)
+}
+
+func spansMultipleDays(targets []target) bool {
+	for i := 1; i < len(targets); i++ {
+		if targets[i].key() != targets[0].key() {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+)
+
+func listCommand(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	var sel selection
+
+	fs := newFlagSet("list", stderr)
+	sel.registerTargetFlags(fs)
+
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	targets, err := sel.targets()
+	if err != nil {
+		return err
+	}
+
+	for _, t := range targets {
+		fmt.Fprintf(stdout, "%d\t%d\t%d\n", t.year, t.day.Number(), t.part)
+	}
+
+	return nil
+}
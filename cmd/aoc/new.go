@@ -0,0 +1,211 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"fmt"
+	"go/format"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+//go:embed templates/*.tmpl
+var templateFS embed.FS
+
+var templates = template.Must(template.ParseFS(templateFS, "templates/*.tmpl"))
+
+var validFileName = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+// scaffold describes the day package generated by aoc new
+type scaffold struct {
+	Module  string
+	Package string
+	Year    int
+	Day     int
+}
+
+func newCommand(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	fs := newFlagSet("new", stderr)
+	year := fs.Int("year", 0, "`year` of the new puzzle (required)")
+	day := fs.Int("day", 0, "`day` of the new puzzle (required)")
+	name := fs.String("name", "puzzle", "base `name` of the generated .go files")
+	dir := fs.String("dir", ".", "`directory` containing go.mod and the year directories")
+
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	if *year < FirstYear {
+		return usageErrorf("--year is required and must be %d or later", FirstYear)
+	}
+
+	if *day < 1 || *day > MaxDays {
+		return usageErrorf("--day is required and must be between 1 and %d", MaxDays)
+	}
+
+	if !validFileName.MatchString(*name) {
+		return usageErrorf("invalid --name %q; use lowercase letters, digits and underscores", *name)
+	}
+
+	module, err := readModulePath(filepath.Join(*dir, "go.mod"))
+	if err != nil {
+		return err
+	}
+
+	s := scaffold{
+		Module:  module,
+		Package: fmt.Sprintf("d%02d", *day),
+		Year:    *year,
+		Day:     *day,
+	}
+
+	yearDir := filepath.Join(*dir, strconv.Itoa(s.Year))
+	dayDir := filepath.Join(yearDir, fmt.Sprintf("%02d", s.Day))
+
+	if _, err := os.Stat(dayDir); err == nil {
+		return fmt.Errorf("%s already exists", dayDir)
+	}
+
+	if err := os.MkdirAll(dayDir, 0755); err != nil {
+		return err
+	}
+
+	file := filepath.Join(dayDir, *name+".go")
+	if err := s.render(file, "day.go.tmpl"); err != nil {
+		return err
+	}
+	fmt.Fprintf(stdout, "created %s\n", file)
+
+	for _, f := range []struct{ name, contents string }{
+		{"input", ""},
+		{"answers.json", "{}\n"},
+	} {
+		file := filepath.Join(dayDir, f.name)
+		if err := os.WriteFile(file, []byte(f.contents), 0644); err != nil {
+			return err
+		}
+		fmt.Fprintf(stdout, "created %s\n", file)
+	}
+
+	// The year's package imports each day so that they get registered, and
+	// cmd/aoc and the examples test import each year
+	yearFile := filepath.Join(yearDir, fmt.Sprintf("y%d.go", s.Year))
+
+	if _, err := os.Stat(yearFile); err == nil {
+		dayImport := path.Join(module, strconv.Itoa(s.Year), fmt.Sprintf("%02d", s.Day))
+		if err := addBlankImport(yearFile, module, dayImport); err != nil {
+			return err
+		}
+		fmt.Fprintf(stdout, "updated %s\n", yearFile)
+		return nil
+	}
+
+	// The new year's package starts out importing just this day
+	if err := s.render(yearFile, "year.go.tmpl"); err != nil {
+		return err
+	}
+	fmt.Fprintf(stdout, "created %s\n", yearFile)
+
+	yearImport := path.Join(module, strconv.Itoa(s.Year))
+
+	for _, file := range []string{
+		filepath.Join(*dir, "cmd", "aoc", "cmd.go"),
+		filepath.Join(*dir, "examples_test.go"),
+	} {
+		if err := addBlankImport(file, module, yearImport); err != nil {
+			return err
+		}
+		fmt.Fprintf(stdout, "updated %s\n", file)
+	}
+
+	return nil
+}
+
+// render executes the named template for s and writes the result, gofmt'd,
+// to file
+func (s *scaffold) render(file string, name string) error {
+	var buf bytes.Buffer
+	if err := templates.ExecuteTemplate(&buf, name, s); err != nil {
+		return err
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+
+	return os.WriteFile(file, src, 0644)
+}
+
+// readModulePath returns the module path declared in the given go.mod file
+func readModulePath(goMod string) (string, error) {
+	data, err := os.ReadFile(goMod)
+	if err != nil {
+		return "", err
+	}
+
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 2 && fields[0] == "module" {
+			return strings.Trim(fields[1], `"`), nil
+		}
+	}
+
+	return "", fmt.Errorf("%s does not declare a module", goMod)
+}
+
+// addBlankImport adds `_ "importPath"` to file, alongside (and sorted among)
+// the blank imports of other packages from module that it already has. Nothing
+// is changed if the import is already present.
+func addBlankImport(file string, module string, importPath string) error {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+
+	lines := strings.Split(string(data), "\n")
+	newLine := fmt.Sprintf("\t_ %q", importPath)
+	prefix := "\t_ \"" + module + "/"
+
+	start, end := -1, -1
+
+	for i, line := range lines {
+		if line == newLine {
+			return nil
+		}
+
+		if strings.HasPrefix(line, prefix) {
+			if start < 0 {
+				start = i
+			}
+			end = i + 1
+		}
+	}
+
+	if start < 0 {
+		return fmt.Errorf("%s: could not find an import block to add %q to", file, importPath)
+	}
+
+	group := append([]string{newLine}, lines[start:end]...)
+	sort.Strings(group)
+
+	var result []string
+	result = append(result, lines[:start]...)
+	result = append(result, group...)
+	result = append(result, lines[end:]...)
+
+	src, err := format.Source([]byte(strings.Join(result, "\n")))
+	if err != nil {
+		return fmt.Errorf("%s: %w", file, err)
+	}
+
+	return os.WriteFile(file, src, 0644)
+}
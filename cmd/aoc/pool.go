@@ -0,0 +1,207 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/matthinz/aoc-golang"
+)
+
+// pool solves targets using a fixed number of workers.
+type pool struct {
+	// number of parts to solve at once
+	jobs int
+
+	// limit on how long each part may run, or 0 for no limit
+	timeout time.Duration
+
+	// where puzzles' log output is written, or nil to discard it
+	logs io.Writer
+
+	// flags for each part's logger (see log.New)
+	logFlags int
+
+	// least important messages to log
+	logLevel aoc.Level
+
+	// if set, each part logs to its own file named after this one (see
+	// target.fileName) instead of to logs
+	logFile string
+
+	// where each part's input comes from
+	inputs aoc.InputProvider
+
+	// profiles to write for each part, if any
+	profile *profiler
+
+	// shows each part's progress, or nil not to
+	progress *progressReporter
+
+	// records each part's simulation, or nil not to
+	visualize *visualizer
+
+	// where each part's exported files go, or nil not to export them
+	export *exporter
+}
+
+// result is what came of solving one target
+type result struct {
+	answer string
+	stats  aoc.Stats
+	err    error
+}
+
+// outcome tracks a target while it is being solved
+type outcome struct {
+	result
+	log  bytes.Buffer
+	done chan struct{}
+}
+
+// solve runs each target against the input from p.inputs and passes the
+// results to report in the same order as targets, regardless of the order in
+// which they finish. Failing to get a part's input counts as that part
+// failing.
+//
+// When more than one job is allowed, each part logs into its own buffer,
+// which is copied to p.logs just before that part is reported. With a single
+// job, log output is written as it happens.
+func (p *pool) solve(ctx context.Context, targets []target, report func(t target, r result)) error {
+	outcomes := make([]*outcome, len(targets))
+	for i := range outcomes {
+		outcomes[i] = &outcome{done: make(chan struct{})}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+
+	indices := make(chan int)
+	var wg sync.WaitGroup
+
+	defer func() {
+		cancel()
+		wg.Wait()
+	}()
+
+	for w := 0; w < p.jobs; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indices {
+				o := outcomes[i]
+				t := targets[i]
+				input, err := p.inputs.Input(ctx, t.year, t.day.Number())
+				if err != nil {
+					o.err = fmt.Errorf("reading input: %w", err)
+				} else {
+					o.result = p.solveOne(ctx, t, input, o)
+				}
+				close(o.done)
+			}
+		}()
+	}
+
+	go func() {
+		defer close(indices)
+		for i := range targets {
+			select {
+			case indices <- i:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	for i, t := range targets {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		o := outcomes[i]
+
+		select {
+		case <-o.done:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+
+		if p.logs != nil && o.log.Len() > 0 {
+			p.logs.Write(o.log.Bytes())
+		}
+
+		report(t, o.result)
+	}
+
+	return nil
+}
+
+// solveOne solves t against input, showing its progress, profiling it,
+// visualizing it and exporting its files if asked to. Failing to write a
+// profile, log file or visualization counts as the part failing.
+func (p *pool) solveOne(ctx context.Context, t target, input string, o *outcome) result {
+	l, closeLog, err := p.logger(t, o)
+	if err != nil {
+		return result{err: fmt.Errorf("opening log: %w", err)}
+	}
+
+	stop, err := p.profile.start(t)
+	if err != nil {
+		closeLog()
+		return result{err: fmt.Errorf("starting profile: %w", err)}
+	}
+
+	solveCtx, stopProgress := p.progress.watch(ctx, t, l)
+	solveCtx, showFrames := p.visualize.start(solveCtx, t)
+	solveCtx = p.export.start(solveCtx, t)
+
+	var r result
+	r.answer, r.stats, r.err = t.solve(solveCtx, input, l, p.timeout)
+
+	stopProgress()
+
+	if err := stop(); err != nil && r.err == nil {
+		r.err = fmt.Errorf("writing profile: %w", err)
+	}
+
+	if r.err == nil {
+		if err := showFrames(ctx); err != nil {
+			r.err = fmt.Errorf("visualizing: %w", err)
+		}
+	}
+
+	if err := closeLog(); err != nil && r.err == nil {
+		r.err = fmt.Errorf("writing log: %w", err)
+	}
+
+	return r
+}
+
+// logger returns the logger t should use while producing o, and a function
+// to call once t is done with it. Each line is tagged with t.
+func (p *pool) logger(t target, o *outcome) (*aoc.Logger, func() error, error) {
+	var w io.Writer
+	closeLog := func() error { return nil }
+
+	switch {
+	case p.logFile != "":
+		f, err := os.Create(t.fileName(p.logFile))
+		if err != nil {
+			return nil, nil, err
+		}
+		w = f
+		closeLog = f.Close
+	case p.logs == nil:
+		return aoc.DiscardLogger(), closeLog, nil
+	case p.jobs <= 1:
+		w = p.logs
+	default:
+		w = &o.log
+	}
+
+	return aoc.NewLogger(w, t.String()+": ", p.logFlags|log.Lmsgprefix, p.logLevel), closeLog, nil
+}
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"runtime/pprof"
+	"runtime/trace"
+)
+
+// profiler writes a CPU profile, memory profile and/or execution trace for
+// each part solved. Each is written to its own file, named after the one given
+// on the command line with the part added, e.g. --cpuprofile cpu.pprof writes
+// cpu-2021-22-part2.pprof for 2021 day 22 part 2.
+//
+// Profiles and traces cover the whole process, so only one part can be
+// profiled at a time.
+type profiler struct {
+	cpu   string
+	mem   string
+	trace string
+
+	// called with the name of each file once it has been written
+	wrote func(name string)
+}
+
+// registerFlags adds the --cpuprofile, --memprofile and --trace flags to fs
+func (p *profiler) registerFlags(fs *flag.FlagSet) {
+	fs.StringVar(&p.cpu, "cpuprofile", "", "write a CPU profile for each part to `file`, with the year, day and part added to the name")
+	fs.StringVar(&p.mem, "memprofile", "", "write a memory profile for each part to `file`, with the year, day and part added to the name")
+	fs.StringVar(&p.trace, "trace", "", "write an execution trace for each part to `file`, with the year, day and part added to the name")
+}
+
+// enabled returns whether any kind of profile was asked for
+func (p *profiler) enabled() bool {
+	return p != nil && (p.cpu != "" || p.mem != "" || p.trace != "")
+}
+
+// checkJobs validates --jobs against the profiling flags
+func (p *profiler) checkJobs(jobs int) error {
+	if p.enabled() && jobs > 1 {
+		return usageErrorf("--cpuprofile, --memprofile and --trace cannot be used with --jobs greater than 1")
+	}
+	return nil
+}
+
+// start begins profiling t. The function returned stops profiling and writes
+// the files; it must be called once t has been solved.
+func (p *profiler) start(t target) (func() error, error) {
+	if !p.enabled() {
+		return func() error { return nil }, nil
+	}
+
+	var cpuFile, traceFile *os.File
+	var err error
+
+	closeAll := func() {
+		if cpuFile != nil {
+			pprof.StopCPUProfile()
+			cpuFile.Close()
+		}
+		if traceFile != nil {
+			trace.Stop()
+			traceFile.Close()
+		}
+	}
+
+	if p.cpu != "" {
+		if cpuFile, err = os.Create(t.fileName(p.cpu)); err != nil {
+			return nil, err
+		}
+		if err = pprof.StartCPUProfile(cpuFile); err != nil {
+			cpuFile.Close()
+			return nil, err
+		}
+	}
+
+	if p.trace != "" {
+		if traceFile, err = os.Create(t.fileName(p.trace)); err != nil {
+			closeAll()
+			return nil, err
+		}
+		if err = trace.Start(traceFile); err != nil {
+			traceFile.Close()
+			traceFile = nil
+			closeAll()
+			return nil, err
+		}
+	}
+
+	stop := func() error {
+		if cpuFile != nil {
+			pprof.StopCPUProfile()
+			if err := p.finish(cpuFile); err != nil {
+				return err
+			}
+		}
+
+		if traceFile != nil {
+			trace.Stop()
+			if err := p.finish(traceFile); err != nil {
+				return err
+			}
+		}
+
+		if p.mem != "" {
+			return p.writeMemProfile(t.fileName(p.mem))
+		}
+
+		return nil
+	}
+
+	return stop, nil
+}
+
+// writeMemProfile saves the allocation profile to name. Like go test
+// -memprofile, it covers everything allocated since the process started, so
+// when several parts are run, compare each part's profile against the one
+// before it using go tool pprof -diff_base.
+func (p *profiler) writeMemProfile(name string) error {
+	f, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+
+	// Bring the profile up to date with what the part allocated
+	runtime.GC()
+
+	if err := pprof.Lookup("allocs").WriteTo(f, 0); err != nil {
+		f.Close()
+		return err
+	}
+
+	return p.finish(f)
+}
+
+// finish closes f and reports that it was written
+func (p *profiler) finish(f *os.File) error {
+	if err := f.Close(); err != nil {
+		return err
+	}
+	if p.wrote != nil {
+		p.wrote(f.Name())
+	}
+	return nil
+}
+
+// reportWrote returns a function for profiler.wrote that tells the user about
+// each file written by the named command
+func reportWrote(command string, w io.Writer) func(string) {
+	return func(name string) {
+		fmt.Fprintf(w, "aoc %s: wrote %s\n", command, name)
+	}
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/matthinz/aoc-golang"
+)
+
+// clearLine moves the cursor back to the start of the line and erases it
+const clearLine = "\r\033[K"
+
+// How often progress is shown
+const (
+	progressLineInterval = 250 * time.Millisecond
+	progressLogInterval  = 10 * time.Second
+)
+
+// progressReporter shows how each part is getting on while it is solved, for
+// puzzlers that report their progress (see aoc.ProgressFrom).
+type progressReporter struct {
+	// where the progress line is drawn, if line is true
+	w io.Writer
+
+	// redraw a single line in place rather than logging periodically
+	line bool
+
+	// how often progress is shown
+	interval time.Duration
+
+	// guards writes to w, and whether the line currently has progress on it
+	mu    sync.Mutex
+	drawn bool
+}
+
+// newProgressReporter returns a reporter suited to w: a live progress line if
+// w is a terminal and only one part runs at a time, and periodic log lines
+// otherwise.
+func newProgressReporter(w io.Writer, jobs int) *progressReporter {
+	if jobs == 1 && isTerminal(w) {
+		return &progressReporter{w: w, line: true, interval: progressLineInterval}
+	}
+	return &progressReporter{interval: progressLogInterval}
+}
+
+// watch returns a copy of ctx that t's puzzler can report its progress to,
+// and shows that progress until stop is called. Periodic log lines go to l.
+func (r *progressReporter) watch(ctx context.Context, t target, l *aoc.Logger) (context.Context, func()) {
+	if r == nil {
+		return ctx, func() {}
+	}
+
+	tracker := aoc.NewTracker()
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+
+	go func() {
+		defer close(stopped)
+
+		ticker := time.NewTicker(r.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				status := tracker.Status()
+				if !status.Started() {
+					continue
+				}
+				if r.line {
+					r.draw(fmt.Sprintf("%s: %s", t.String(), status))
+				} else {
+					l.Infof("progress: %s", status)
+				}
+			case <-done:
+				if r.line {
+					r.draw("")
+				}
+				return
+			}
+		}
+	}()
+
+	stop := func() {
+		close(done)
+		<-stopped
+	}
+
+	return aoc.WithProgress(ctx, tracker), stop
+}
+
+// draw replaces the progress line with text
+func (r *progressReporter) draw(text string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.drawn || text != "" {
+		fmt.Fprint(r.w, clearLine, text)
+	}
+	r.drawn = text != ""
+}
+
+// Write clears the progress line and writes p to w in its place. The line is
+// redrawn next time round. Puzzles' log output goes through here in line mode
+// so that the two don't end up mixed together.
+func (r *progressReporter) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.drawn {
+		fmt.Fprint(r.w, clearLine)
+		r.drawn = false
+	}
+
+	return r.w.Write(p)
+}
+
+// isTerminal returns whether w is a terminal rather than e.g. a file or pipe
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+
+	return info.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,185 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"time"
+
+	"github.com/matthinz/aoc-golang"
+)
+
+// Output formats supported by aoc run
+const (
+	formatText = "text"
+	formatJSON = "json"
+)
+
+// Statuses reported by aoc run --format json
+const (
+	runStatusSolved  = "solved"
+	runStatusFailed  = "failed"
+	runStatusTimeout = "timeout"
+)
+
+// runRecord describes a single part in the output of aoc run --format json
+type runRecord struct {
+	Year     int           `json:"year"`
+	Day      int           `json:"day"`
+	Part     int           `json:"part"`
+	Status   string        `json:"status"`
+	Answer   string        `json:"answer"`
+	Duration time.Duration `json:"duration_ns"`
+	Error    string        `json:"error,omitempty"`
+}
+
+func runCommand(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	var sel selection
+
+	fs := newFlagSet("run", stderr)
+	sel.registerTargetFlags(fs)
+	sel.registerInputFlags(fs)
+	timeout := registerTimeoutFlag(fs)
+	options := registerOptionFlag(fs)
+	jobs := registerJobsFlag(fs)
+	profile := profiler{wrote: reportWrote("run", stderr)}
+	profile.registerFlags(fs)
+	visualize := visualizer{out: stdout, messages: stderr, command: "run"}
+	visualize.registerFlags(fs)
+	export := exporter{wrote: reportWrote("run", stderr)}
+	export.registerFlags(fs)
+	showStats, statsFile := registerStatsFlags(fs)
+	var logs logOptions
+	logs.register(fs)
+	format := fs.String("format", formatText, "output `format`: text (tab-separated) or json (one object per line)")
+
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	if *format != formatText && *format != formatJSON {
+		return usageErrorf("unknown format %q", *format)
+	}
+
+	if err := checkJobs(*jobs); err != nil {
+		return err
+	}
+
+	if err := profile.checkJobs(*jobs); err != nil {
+		return err
+	}
+
+	if err := visualize.check(*jobs); err != nil {
+		return err
+	}
+
+	// terminal playback shares stdout with the results, which would no longer
+	// be valid JSON
+	if visualize.target == visualizeTerminal && *format == formatJSON {
+		return usageErrorf("--visualize terminal cannot be used with --format json")
+	}
+
+	level, err := logs.level()
+	if err != nil {
+		return err
+	}
+
+	targets, err := sel.targets()
+	if err != nil {
+		return err
+	}
+
+	inputs, err := sel.inputProvider()
+	if err != nil {
+		return err
+	}
+
+	p := pool{
+		jobs:      *jobs,
+		timeout:   *timeout,
+		logs:      stderr,
+		logFlags:  log.Default().Flags(),
+		logLevel:  level,
+		logFile:   logs.file,
+		inputs:    inputs,
+		profile:   &profile,
+		progress:  newProgressReporter(stderr, *jobs),
+		visualize: &visualize,
+		export:    &export,
+	}
+
+	if p.progress.line {
+		p.logs = p.progress
+	}
+
+	failed := false
+	var stats []partStats
+	enc := json.NewEncoder(stdout)
+
+	err = p.solve(aoc.WithOptions(ctx, options), targets, func(t target, r result) {
+		stats = append(stats, newPartStats(t, r))
+
+		if *format == formatJSON {
+			enc.Encode(newRunRecord(t, r))
+			failed = failed || r.err != nil
+			return
+		}
+
+		if r.err != nil {
+			// Keep going so that one broken part doesn't hide the others
+			fmt.Fprintf(stderr, "aoc run: %s: %s\n", t.String(), r.err)
+			failed = true
+			return
+		}
+
+		// Output is tab-separated so that it is easy for scripts to consume
+		fmt.Fprintf(stdout, "%d\t%d\t%d\t%s\n", t.year, t.day.Number(), t.part, r.answer)
+	})
+
+	// Report on whatever finished, even if we were interrupted
+	if *showStats {
+		fmt.Fprintln(stderr)
+		if statsErr := printStatsTable(stderr, stats); statsErr != nil {
+			return statsErr
+		}
+	}
+
+	if *statsFile != "" {
+		if statsErr := writeStatsFile(*statsFile, stats); statsErr != nil {
+			return statsErr
+		}
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if failed {
+		return errPuzzlesFailed
+	}
+
+	return nil
+}
+
+func newRunRecord(t target, r result) runRecord {
+	record := runRecord{
+		Year:     t.year,
+		Day:      t.day.Number(),
+		Part:     t.part,
+		Status:   runStatusSolved,
+		Answer:   r.answer,
+		Duration: r.stats.Duration,
+	}
+
+	if r.err != nil {
+		record.Status = runStatusFailed
+		if isTimeout(r.err) {
+			record.Status = runStatusTimeout
+		}
+		record.Error = r.err.Error()
+	}
+
+	return record
+}
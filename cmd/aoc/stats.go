@@ -0,0 +1,89 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/matthinz/aoc-golang"
+)
+
+// partStats records the resources used by a single part
+type partStats struct {
+	Year  int    `json:"year"`
+	Day   int    `json:"day"`
+	Part  int    `json:"part"`
+	Error string `json:"error,omitempty"`
+	aoc.Stats
+}
+
+func newPartStats(t target, r result) partStats {
+	s := partStats{
+		Year:  t.year,
+		Day:   t.day.Number(),
+		Part:  t.part,
+		Stats: r.stats,
+	}
+	if r.err != nil {
+		s.Error = r.err.Error()
+	}
+	return s
+}
+
+// printStatsTable writes a summary of rows to w, followed by totals
+func printStatsTable(w io.Writer, rows []partStats) error {
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+
+	fmt.Fprintln(tw, "YEAR\tDAY\tPART\tTIME\tALLOCS\tALLOCATED\tPEAK HEAP")
+
+	var total aoc.Stats
+
+	for _, row := range rows {
+		fmt.Fprintf(
+			tw,
+			"%d\t%d\t%d\t%v\t%d\t%s\t%s\n",
+			row.Year,
+			row.Day,
+			row.Part,
+			row.Duration.Round(time.Microsecond),
+			row.Allocs,
+			aoc.FormatBytes(row.AllocBytes),
+			aoc.FormatBytes(row.PeakHeap),
+		)
+
+		total.Duration += row.Duration
+		total.Allocs += row.Allocs
+		total.AllocBytes += row.AllocBytes
+		if row.PeakHeap > total.PeakHeap {
+			total.PeakHeap = row.PeakHeap
+		}
+	}
+
+	fmt.Fprintf(
+		tw,
+		"total\t\t\t%v\t%d\t%s\t%s\n",
+		total.Duration.Round(time.Microsecond),
+		total.Allocs,
+		aoc.FormatBytes(total.AllocBytes),
+		aoc.FormatBytes(total.PeakHeap),
+	)
+
+	return tw.Flush()
+}
+
+// writeStatsFile saves rows to the file at name as a JSON array
+func writeStatsFile(name string, rows []partStats) error {
+	if rows == nil {
+		rows = []partStats{}
+	}
+
+	data, err := json.MarshalIndent(rows, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(name, append(data, '\n'), 0644)
+}
@@ -0,0 +1,188 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/matthinz/aoc-golang"
+)
+
+// Statuses reported by aoc verify
+const (
+	statusPass    = "PASS"
+	statusFail    = "FAIL"
+	statusMissing = "MISSING"
+	statusTimeout = "TIMEOUT"
+)
+
+// verifyFormat lays out a row of aoc verify's table. Rows are written as each
+// part finishes, so the columns are fixed-width rather than computed.
+const verifyFormat = "%-4s  %3s  %4s  %-7s  %s"
+
+// recordedDay collects the answers computed for a day by aoc verify --record
+type recordedDay struct {
+	year    int
+	day     aoc.Day
+	answers aoc.Answers
+}
+
+func verifyCommand(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	var sel selection
+
+	fs := newFlagSet("verify", stderr)
+	sel.registerTargetFlags(fs)
+	timeout := registerTimeoutFlag(fs)
+	jobs := registerJobsFlag(fs)
+	profile := profiler{wrote: reportWrote("verify", stderr)}
+	profile.registerFlags(fs)
+	record := fs.Bool("record", false, "write the answers computed to each day's answers.json")
+	dir := fs.String("dir", ".", "`directory` containing the year directories, used with --record")
+
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	if err := checkJobs(*jobs); err != nil {
+		return err
+	}
+
+	if err := profile.checkJobs(*jobs); err != nil {
+		return err
+	}
+
+	targets, err := sel.targets()
+	if err != nil {
+		return err
+	}
+
+	expected := make(map[dayKey]aoc.Answers)
+
+	for _, t := range targets {
+		if _, found := expected[t.key()]; found {
+			continue
+		}
+
+		answers, err := t.day.Answers()
+		if err != nil {
+			return fmt.Errorf("%d day %d: %w", t.year, t.day.Number(), err)
+		}
+		expected[t.key()] = answers
+	}
+
+	// Recorded answers are for the embedded inputs
+	p := pool{
+		jobs:    *jobs,
+		timeout: *timeout,
+		inputs:  aoc.NewEmbeddedInputProvider(),
+		profile: &profile,
+	}
+	counts := make(map[string]int)
+	var recorded []*recordedDay
+
+	printVerifyRow(stdout, "YEAR", "DAY", "PART", "STATUS", "DETAIL")
+
+	err = p.solve(ctx, targets, func(t target, r result) {
+		status, detail := verifyStatus(expected[t.key()], t.part, r.answer, r.err)
+		counts[status]++
+
+		printVerifyRow(
+			stdout,
+			strconv.Itoa(t.year),
+			strconv.Itoa(t.day.Number()),
+			strconv.Itoa(t.part),
+			status,
+			detail,
+		)
+
+		if *record && r.err == nil {
+			if len(recorded) == 0 || recorded[len(recorded)-1].key() != t.key() {
+				day, _ := t.day.Answers()
+				recorded = append(recorded, &recordedDay{t.year, t.day, day})
+			}
+			recorded[len(recorded)-1].answers[t.part] = r.answer
+		}
+	})
+
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(
+		stdout,
+		"\n%d passed, %d failed, %d timed out, %d missing\n",
+		counts[statusPass],
+		counts[statusFail],
+		counts[statusTimeout],
+		counts[statusMissing],
+	)
+
+	for _, r := range recorded {
+		file, err := r.write(*dir)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(stderr, "aoc verify: wrote %s\n", file)
+	}
+
+	if counts[statusFail] > 0 || counts[statusTimeout] > 0 {
+		return errPuzzlesFailed
+	}
+
+	return nil
+}
+
+// verifyStatus compares the outcome of running a part against its recorded
+// answer. Parts without a recorded answer are reported as missing rather than
+// failed, so that new days don't break verification.
+func verifyStatus(expected aoc.Answers, part int, answer string, err error) (string, string) {
+	if err != nil {
+		if isTimeout(err) {
+			return statusTimeout, err.Error()
+		}
+		return statusFail, err.Error()
+	}
+
+	want, found := expected[part]
+
+	if !found {
+		return statusMissing, fmt.Sprintf("got %q", answer)
+	}
+
+	if answer != want {
+		return statusFail, fmt.Sprintf("expected %q, got %q", want, answer)
+	}
+
+	return statusPass, ""
+}
+
+func printVerifyRow(w io.Writer, year, day, part, status, detail string) {
+	row := fmt.Sprintf(verifyFormat, year, day, part, status, detail)
+	fmt.Fprintln(w, strings.TrimRight(row, " "))
+}
+
+func (r *recordedDay) key() dayKey {
+	return dayKey{r.year, r.day.Number()}
+}
+
+// write saves r's answers to the answers.json next to the day's input in dir.
+// It returns the name of the file written.
+func (r *recordedDay) write(dir string) (string, error) {
+	dayDir := filepath.Join(dir, strconv.Itoa(r.year), fmt.Sprintf("%02d", r.day.Number()))
+
+	if _, err := os.Stat(filepath.Join(dayDir, "input")); err != nil {
+		return "", fmt.Errorf("%s does not contain a day's input (use --dir to point at the repository root)", dayDir)
+	}
+
+	file := filepath.Join(dayDir, "answers.json")
+
+	if err := os.WriteFile(file, []byte(r.answers.Format()), 0644); err != nil {
+		return "", err
+	}
+
+	return file, nil
+}
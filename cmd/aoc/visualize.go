@@ -0,0 +1,147 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/matthinz/aoc-golang/visual"
+)
+
+// visualizeTerminal is the --visualize target that plays frames in the
+// terminal rather than writing them to a file
+const visualizeTerminal = "terminal"
+
+// visualizer records the frames of each part's simulation, then either plays
+// them in the terminal or exports them, depending on --visualize:
+//
+//	terminal  plays each part as an animation on stdout once it is solved
+//	*.gif     writes each part to its own animated GIF
+//	*.png     writes each frame of each part to its own PNG
+//
+// File names have the part added as they do for profiles, e.g.
+// --visualize out/sim.gif writes out/sim-2021-11-part1.gif.
+type visualizer struct {
+	target string
+	delay  time.Duration
+
+	// where animations are played
+	out io.Writer
+
+	// where the user is told about files written, and parts that had nothing
+	// to show
+	messages io.Writer
+	command  string
+}
+
+// registerFlags adds the --visualize and --frame-delay flags to fs
+func (v *visualizer) registerFlags(fs *flag.FlagSet) {
+	fs.StringVar(&v.target, "visualize", "", "record each part's simulation and play it in the `terminal`, or write it to a .gif or .png file, with the year, day and part added to the name")
+	fs.DurationVar(&v.delay, "frame-delay", 100*time.Millisecond, "with --visualize, show each frame for `d`")
+}
+
+// enabled returns whether --visualize was given
+func (v *visualizer) enabled() bool {
+	return v != nil && v.target != ""
+}
+
+// check validates --visualize and --frame-delay against each other and
+// --jobs
+func (v *visualizer) check(jobs int) error {
+	if !v.enabled() {
+		return nil
+	}
+
+	if v.delay < 0 {
+		return usageErrorf("--frame-delay cannot be negative")
+	}
+
+	switch {
+	case v.target == visualizeTerminal:
+		if jobs > 1 {
+			return usageErrorf("--visualize terminal cannot be used with --jobs greater than 1")
+		}
+	case v.isGIF(), v.isPNG():
+	default:
+		return usageErrorf("--visualize must be %q or a file ending in .gif or .png", visualizeTerminal)
+	}
+
+	return nil
+}
+
+// start begins recording t's frames. The function returned shows or writes
+// them; it must be called once t has been solved.
+func (v *visualizer) start(ctx context.Context, t target) (context.Context, func(context.Context) error) {
+	if !v.enabled() {
+		return ctx, func(context.Context) error { return nil }
+	}
+
+	reel := &visual.Reel{}
+
+	return visual.WithRecorder(ctx, reel), func(ctx context.Context) error {
+		if len(reel.Frames) == 0 {
+			fmt.Fprintf(v.messages, "aoc %s: %s: nothing to visualize\n", v.command, t.String())
+			return nil
+		}
+		return v.finish(ctx, t, reel.Frames)
+	}
+}
+
+func (v *visualizer) finish(ctx context.Context, t target, frames []*visual.Frame) error {
+	switch {
+	case v.isGIF():
+		name := t.fileName(v.target)
+		if err := writeGIF(name, frames, v.delay); err != nil {
+			return err
+		}
+		v.wrote(name)
+
+	case v.isPNG():
+		names, err := visual.WritePNGs(t.fileName(v.target), frames)
+		switch len(names) {
+		case 0:
+		case 1:
+			v.wrote(names[0])
+		default:
+			// one line per frame would bury everything else
+			v.wrote(names[0] + " through " + names[len(names)-1])
+		}
+		return err
+
+	default:
+		return visual.Play(ctx, v.out, frames, v.delay)
+	}
+
+	return nil
+}
+
+func (v *visualizer) wrote(name string) {
+	fmt.Fprintf(v.messages, "aoc %s: wrote %s\n", v.command, name)
+}
+
+func (v *visualizer) isGIF() bool {
+	return strings.EqualFold(filepath.Ext(v.target), ".gif")
+}
+
+func (v *visualizer) isPNG() bool {
+	return strings.EqualFold(filepath.Ext(v.target), ".png")
+}
+
+func writeGIF(name string, frames []*visual.Frame, delay time.Duration) error {
+	f, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+
+	if err := visual.WriteGIF(f, frames, delay); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
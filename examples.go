@@ -0,0 +1,50 @@
+package aoc
+
+import "strings"
+
+// Example is a worked example from a puzzle's description: a small input and
+// the answers the puzzle gives for it.
+type Example struct {
+	Input string
+
+	// Expected answers by part number (starting at 1). Parts the example
+	// doesn't cover are not present.
+	Answers Answers
+}
+
+// WithExample returns a copy of d that carries an example with the given
+// input and answers, starting with part 1, e.g.
+//
+//	aoc.NewDay(6, defaultInput, Puzzle1, Puzzle2).
+//		WithExample("3,4,3,1,2", "5934", "26984457539")
+//
+// Use "" for any part the example doesn't have an answer for. Blank lines at
+// the start and end of input are removed, so that a multi-line example can be
+// written as a raw string that starts on its own line.
+func (d Day) WithExample(input string, answers ...string) Day {
+	example := Example{
+		Input:   strings.Trim(input, "\n"),
+		Answers: make(Answers),
+	}
+
+	for i, answer := range answers {
+		if answer != "" {
+			example.Answers[i+1] = answer
+		}
+	}
+
+	// copy so that days made from the same original don't share examples
+	examples := make([]Example, len(d.examples), len(d.examples)+1)
+	copy(examples, d.examples)
+	d.examples = append(examples, example)
+
+	return d
+}
+
+// Examples returns the worked examples d carries, in the order they were
+// added.
+func (d *Day) Examples() []Example {
+	result := make([]Example, len(d.examples))
+	copy(result, d.examples)
+	return result
+}
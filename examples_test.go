@@ -0,0 +1,104 @@
+package aoc_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/matthinz/aoc-golang"
+
+	// register every day so that their examples get checked
+	_ "github.com/matthinz/aoc-golang/2020"
+	_ "github.com/matthinz/aoc-golang/2021"
+)
+
+// How long any one example gets to run. Examples are meant to be small, so
+// this is generous.
+const exampleTimeout = 30 * time.Second
+
+// TestExamples solves every registered day's worked examples and checks the
+// answers.
+func TestExamples(t *testing.T) {
+	for _, year := range aoc.Years() {
+		for _, day := range year.Days() {
+			puzzles := day.Puzzles()
+
+			for i, example := range day.Examples() {
+				for part := 1; part <= len(puzzles); part++ {
+					expected, found := example.Answers[part]
+					if !found {
+						continue
+					}
+
+					name := fmt.Sprintf("%d/%02d/example%d/part%d", year.Number(), day.Number(), i+1, part)
+					puzzle := puzzles[part-1]
+					input := example.Input
+
+					t.Run(name, func(t *testing.T) {
+						ctx, cancel := context.WithTimeout(context.Background(), exampleTimeout)
+						defer cancel()
+
+						var logs strings.Builder
+						l := aoc.NewLogger(&logs, "", 0, aoc.LevelDebug)
+
+						actual, err := puzzle.Solve(ctx, strings.NewReader(input), l)
+						if err != nil {
+							t.Fatalf("%v\n%s", err, logs.String())
+						}
+
+						if actual != expected {
+							t.Errorf("Expected %s, got %s\n%s", expected, actual, logs.String())
+						}
+					})
+				}
+			}
+		}
+	}
+}
+
+func TestWithExample(t *testing.T) {
+	base := aoc.NewDay(1, "")
+
+	day := base.
+		WithExample("\n1\n2\n\n", "3", "").
+		WithExample("4", "", "5")
+
+	if len(base.Examples()) != 0 {
+		t.Errorf("WithExample should not change the day it was called on")
+	}
+
+	examples := day.Examples()
+	if len(examples) != 2 {
+		t.Fatalf("Expected 2 examples, got %d", len(examples))
+	}
+
+	if examples[0].Input != "1\n2" {
+		t.Errorf("Expected surrounding blank lines to be removed, got %q", examples[0].Input)
+	}
+
+	if len(examples[0].Answers) != 1 || examples[0].Answers[1] != "3" {
+		t.Errorf("Expected only a part 1 answer, got %v", examples[0].Answers)
+	}
+
+	if len(examples[1].Answers) != 1 || examples[1].Answers[2] != "5" {
+		t.Errorf("Expected only a part 2 answer, got %v", examples[1].Answers)
+	}
+}
+
+func TestExamplesDontLeakIntoOtherDays(t *testing.T) {
+	base := aoc.NewDay(1, "").WithExample("a", "1")
+
+	// both are built from base, which has spare capacity after appending
+	first := base.WithExample("b", "2")
+	second := base.WithExample("c", "3")
+
+	if examples := first.Examples(); examples[1].Input != "b" {
+		t.Errorf("Expected first's second example to be b, got %q", examples[1].Input)
+	}
+
+	if examples := second.Examples(); examples[1].Input != "c" {
+		t.Errorf("Expected second's second example to be c, got %q", examples[1].Input)
+	}
+}
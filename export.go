@@ -0,0 +1,32 @@
+package aoc
+
+import (
+	"context"
+	"io"
+)
+
+// Exporter creates files for a puzzler to write data to that isn't part of
+// its answer, e.g. every step it took, for plotting or looking through
+// afterwards. Puzzlers get theirs from their context using ExporterFrom.
+type Exporter interface {
+	// Create returns a new file called name, e.g. "trace.csv", for the
+	// puzzler to write to and then close. Where the file ends up, and what it
+	// is finally called, is up to the Exporter.
+	Create(name string) (io.WriteCloser, error)
+}
+
+type exporterKey struct{}
+
+// WithExporter returns a copy of ctx that carries e, for a puzzler to export
+// files to.
+func WithExporter(ctx context.Context, e Exporter) context.Context {
+	return context.WithValue(ctx, exporterKey{}, e)
+}
+
+// ExporterFrom returns the Exporter carried by ctx, or nil if there isn't
+// one. Puzzlers should only gather data for export when there is somewhere
+// to export it to.
+func ExporterFrom(ctx context.Context) Exporter {
+	e, _ := ctx.Value(exporterKey{}).(Exporter)
+	return e
+}
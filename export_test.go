@@ -0,0 +1,43 @@
+package aoc
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+)
+
+type bufferExporter map[string]*bytes.Buffer
+
+type nopCloser struct {
+	*bytes.Buffer
+}
+
+func (e bufferExporter) Create(name string) (io.WriteCloser, error) {
+	e[name] = &bytes.Buffer{}
+	return nopCloser{e[name]}, nil
+}
+
+func (nopCloser) Close() error {
+	return nil
+}
+
+func TestExporterFrom(t *testing.T) {
+	if e := ExporterFrom(context.Background()); e != nil {
+		t.Errorf("Expected no exporter, got %v", e)
+	}
+
+	exported := bufferExporter{}
+	ctx := WithExporter(context.Background(), exported)
+
+	f, err := ExporterFrom(ctx).Create("trace.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	io.WriteString(f, "a,b\n")
+	f.Close()
+
+	if buf := exported["trace.csv"]; buf == nil || buf.String() != "a,b\n" {
+		t.Errorf("Expected trace.csv to be exported, got %v", exported)
+	}
+}
@@ -0,0 +1,55 @@
+package geom
+
+import "fmt"
+
+// Box is an axis-aligned box of Point3s. Both Min and Max are inside the box,
+// so a box with Min == Max holds a single point.
+type Box struct {
+	Min, Max Point3
+}
+
+// NewBox returns the smallest box containing both a and b
+func NewBox(a, b Point3) Box {
+	return Box{a.Min(b), a.Max(b)}
+}
+
+// Empty returns whether b holds no points at all, i.e. Min is beyond Max
+// along some axis
+func (b Box) Empty() bool {
+	return b.Min.X > b.Max.X || b.Min.Y > b.Max.Y || b.Min.Z > b.Max.Z
+}
+
+// Size returns the number of points along each edge of b
+func (b Box) Size() Point3 {
+	if b.Empty() {
+		return Point3{}
+	}
+	return b.Max.Sub(b.Min).Add(Point3{1, 1, 1})
+}
+
+// Volume returns the number of points in b
+func (b Box) Volume() int {
+	size := b.Size()
+	return size.X * size.Y * size.Z
+}
+
+// Contains returns whether p lies inside b
+func (b Box) Contains(p Point3) bool {
+	return p.X >= b.Min.X && p.X <= b.Max.X &&
+		p.Y >= b.Min.Y && p.Y <= b.Max.Y &&
+		p.Z >= b.Min.Z && p.Z <= b.Max.Z
+}
+
+// Intersect returns the box of points inside both b and other. The second
+// value is false if they do not overlap.
+func (b Box) Intersect(other Box) (Box, bool) {
+	result := Box{b.Min.Max(other.Min), b.Max.Min(other.Max)}
+	if result.Empty() {
+		return Box{}, false
+	}
+	return result, true
+}
+
+func (b Box) String() string {
+	return fmt.Sprintf("%d..%d,%d..%d,%d..%d", b.Min.X, b.Max.X, b.Min.Y, b.Max.Y, b.Min.Z, b.Max.Z)
+}
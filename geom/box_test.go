@@ -0,0 +1,60 @@
+package geom
+
+import "testing"
+
+func TestBoxVolume(t *testing.T) {
+	b := NewBox(Point3{12, 10, 10}, Point3{10, 12, 12})
+
+	if b.Min != (Point3{10, 10, 10}) || b.Max != (Point3{12, 12, 12}) {
+		t.Errorf("NewBox did not normalize its corners: %v", b)
+	}
+
+	if b.Size() != (Point3{3, 3, 3}) || b.Volume() != 27 {
+		t.Errorf("Expected a 3x3x3 box, got %v (%d)", b.Size(), b.Volume())
+	}
+
+	single := Box{Point3{1, 1, 1}, Point3{1, 1, 1}}
+	if single.Volume() != 1 {
+		t.Errorf("Expected a single point box to have volume 1, got %d", single.Volume())
+	}
+
+	empty := Box{Point3{1, 1, 1}, Point3{0, 1, 1}}
+	if !empty.Empty() || empty.Volume() != 0 {
+		t.Errorf("Expected %v to be empty", empty)
+	}
+}
+
+func TestBoxIntersect(t *testing.T) {
+	a := Box{Point3{10, 10, 10}, Point3{12, 12, 12}}
+	b := Box{Point3{11, 11, 11}, Point3{13, 13, 13}}
+
+	overlap, ok := a.Intersect(b)
+	if !ok {
+		t.Fatal("Expected boxes to overlap")
+	}
+
+	if expected := (Box{Point3{11, 11, 11}, Point3{12, 12, 12}}); overlap != expected {
+		t.Errorf("Expected %v, got %v", expected, overlap)
+	}
+
+	if overlap.Volume() != 8 {
+		t.Errorf("Expected volume 8, got %d", overlap.Volume())
+	}
+
+	c := Box{Point3{13, 0, 0}, Point3{20, 20, 20}}
+	if _, ok := a.Intersect(c); ok {
+		t.Errorf("%v and %v should not overlap", a, c)
+	}
+}
+
+func TestBoxContains(t *testing.T) {
+	b := Box{Point3{-1, -1, -1}, Point3{1, 1, 1}}
+
+	if !b.Contains(Point3{1, -1, 0}) {
+		t.Errorf("Expected corner to be contained")
+	}
+
+	if b.Contains(Point3{2, 0, 0}) {
+		t.Errorf("Expected 2,0,0 to be outside")
+	}
+}
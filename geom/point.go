@@ -0,0 +1,148 @@
+// Package geom provides integer points in two and three dimensions, the 24
+// rotations that map 3D axes onto one another and axis-aligned boxes.
+//
+// Points double as vectors: the offset between two points is itself a point,
+// and can be added to, subtracted from and scaled.
+package geom
+
+import "strconv"
+
+// Point is a position (or vector) in two dimensions.
+type Point struct {
+	X, Y int
+}
+
+// Point3 is a position (or vector) in three dimensions.
+type Point3 struct {
+	X, Y, Z int
+}
+
+// Add returns p + q
+func (p Point) Add(q Point) Point {
+	return Point{p.X + q.X, p.Y + q.Y}
+}
+
+// Sub returns p - q, i.e. the vector from q to p
+func (p Point) Sub(q Point) Point {
+	return Point{p.X - q.X, p.Y - q.Y}
+}
+
+// Scale returns p with each coordinate multiplied by k
+func (p Point) Scale(k int) Point {
+	return Point{p.X * k, p.Y * k}
+}
+
+// Neg returns -p
+func (p Point) Neg() Point {
+	return Point{-p.X, -p.Y}
+}
+
+// Sign returns p with each coordinate replaced by -1, 0 or 1. For a vector
+// that is horizontal, vertical or at 45 degrees, this is the unit step along
+// it.
+func (p Point) Sign() Point {
+	return Point{sign(p.X), sign(p.Y)}
+}
+
+// Min returns the smallest of each of p and q's coordinates
+func (p Point) Min(q Point) Point {
+	return Point{min(p.X, q.X), min(p.Y, q.Y)}
+}
+
+// Max returns the largest of each of p and q's coordinates
+func (p Point) Max(q Point) Point {
+	return Point{max(p.X, q.X), max(p.Y, q.Y)}
+}
+
+// ManhattanDistance returns the distance from p to q moving only along the
+// axes
+func (p Point) ManhattanDistance(q Point) int {
+	return abs(p.X-q.X) + abs(p.Y-q.Y)
+}
+
+// Add returns p + q
+func (p Point3) Add(q Point3) Point3 {
+	return Point3{p.X + q.X, p.Y + q.Y, p.Z + q.Z}
+}
+
+// Sub returns p - q, i.e. the vector from q to p
+func (p Point3) Sub(q Point3) Point3 {
+	return Point3{p.X - q.X, p.Y - q.Y, p.Z - q.Z}
+}
+
+// Scale returns p with each coordinate multiplied by k
+func (p Point3) Scale(k int) Point3 {
+	return Point3{p.X * k, p.Y * k, p.Z * k}
+}
+
+// Neg returns -p
+func (p Point3) Neg() Point3 {
+	return Point3{-p.X, -p.Y, -p.Z}
+}
+
+// Min returns the smallest of each of p and q's coordinates
+func (p Point3) Min(q Point3) Point3 {
+	return Point3{min(p.X, q.X), min(p.Y, q.Y), min(p.Z, q.Z)}
+}
+
+// Max returns the largest of each of p and q's coordinates
+func (p Point3) Max(q Point3) Point3 {
+	return Point3{max(p.X, q.X), max(p.Y, q.Y), max(p.Z, q.Z)}
+}
+
+// ManhattanDistance returns the distance from p to q moving only along the
+// axes
+func (p Point3) ManhattanDistance(q Point3) int {
+	return abs(p.X-q.X) + abs(p.Y-q.Y) + abs(p.Z-q.Z)
+}
+
+// Less orders points by X, then Y, then Z
+func (p Point3) Less(q Point3) bool {
+	if p.X != q.X {
+		return p.X < q.X
+	}
+	if p.Y != q.Y {
+		return p.Y < q.Y
+	}
+	return p.Z < q.Z
+}
+
+func (p Point) String() string {
+	return strconv.Itoa(p.X) + "," + strconv.Itoa(p.Y)
+}
+
+func (p Point3) String() string {
+	return strconv.Itoa(p.X) + "," + strconv.Itoa(p.Y) + "," + strconv.Itoa(p.Z)
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+func sign(n int) int {
+	switch {
+	case n < 0:
+		return -1
+	case n > 0:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,71 @@
+package geom
+
+import "testing"
+
+func TestPointArithmetic(t *testing.T) {
+	p := Point{3, -4}
+	q := Point{-1, 2}
+
+	tests := []struct {
+		name     string
+		actual   Point
+		expected Point
+	}{
+		{"Add", p.Add(q), Point{2, -2}},
+		{"Sub", p.Sub(q), Point{4, -6}},
+		{"Scale", p.Scale(2), Point{6, -8}},
+		{"Neg", p.Neg(), Point{-3, 4}},
+		{"Sign", p.Sign(), Point{1, -1}},
+		{"Min", p.Min(q), Point{-1, -4}},
+		{"Max", p.Max(q), Point{3, 2}},
+	}
+
+	for _, test := range tests {
+		if test.actual != test.expected {
+			t.Errorf("%s: expected %v, got %v", test.name, test.expected, test.actual)
+		}
+	}
+}
+
+func TestManhattanDistance(t *testing.T) {
+	if d := (Point{1, 1}).ManhattanDistance(Point{-2, 5}); d != 7 {
+		t.Errorf("Expected 7, got %d", d)
+	}
+
+	a := Point3{1105, -1205, 1229}
+	b := Point3{-92, -2380, -20}
+	if d := a.ManhattanDistance(b); d != 3621 {
+		t.Errorf("Expected 3621, got %d", d)
+	}
+}
+
+func TestPoint3Arithmetic(t *testing.T) {
+	p := Point3{1, 2, 3}
+	q := Point3{4, -5, 6}
+
+	if actual := p.Add(q); actual != (Point3{5, -3, 9}) {
+		t.Errorf("Add: got %v", actual)
+	}
+
+	if actual := p.Sub(q); actual != (Point3{-3, 7, -3}) {
+		t.Errorf("Sub: got %v", actual)
+	}
+
+	if actual := q.Neg().Scale(2); actual != (Point3{-8, 10, -12}) {
+		t.Errorf("Neg/Scale: got %v", actual)
+	}
+
+	if !p.Less(q) || q.Less(p) || p.Less(p) {
+		t.Errorf("Less is wrong")
+	}
+}
+
+func TestString(t *testing.T) {
+	if s := (Point{-1, 2}).String(); s != "-1,2" {
+		t.Errorf("Wrong string: %s", s)
+	}
+
+	if s := (Point3{1, -2, 3}).String(); s != "1,-2,3" {
+		t.Errorf("Wrong string: %s", s)
+	}
+}
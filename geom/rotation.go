@@ -0,0 +1,89 @@
+package geom
+
+// Matrix is a 3x3 integer matrix, used to rotate Point3s exactly.
+type Matrix [3][3]int
+
+// Identity leaves points where they are
+var Identity = Matrix{
+	{1, 0, 0},
+	{0, 1, 0},
+	{0, 0, 1},
+}
+
+// Quarter turns (90 degrees, counterclockwise when looking back along the
+// axis toward the origin) about each axis
+var (
+	RotateX = Matrix{
+		{1, 0, 0},
+		{0, 0, -1},
+		{0, 1, 0},
+	}
+	RotateY = Matrix{
+		{0, 0, 1},
+		{0, 1, 0},
+		{-1, 0, 0},
+	}
+	RotateZ = Matrix{
+		{0, -1, 0},
+		{1, 0, 0},
+		{0, 0, 1},
+	}
+)
+
+// Rotations holds the 24 distinct rotations that map the axes onto one
+// another: facing along any of the six axis directions, with any of four
+// directions as "up". Identity is first.
+var Rotations = allRotations()
+
+// Apply returns p rotated by m
+func (m Matrix) Apply(p Point3) Point3 {
+	return Point3{
+		m[0][0]*p.X + m[0][1]*p.Y + m[0][2]*p.Z,
+		m[1][0]*p.X + m[1][1]*p.Y + m[1][2]*p.Z,
+		m[2][0]*p.X + m[2][1]*p.Y + m[2][2]*p.Z,
+	}
+}
+
+// Mul returns the product m * n, which applies n and then m
+func (m Matrix) Mul(n Matrix) Matrix {
+	var result Matrix
+	for row := 0; row < 3; row++ {
+		for col := 0; col < 3; col++ {
+			for i := 0; i < 3; i++ {
+				result[row][col] += m[row][i] * n[i][col]
+			}
+		}
+	}
+	return result
+}
+
+// Transpose returns m with its rows and columns swapped. For a rotation, this
+// is the rotation that undoes it.
+func (m Matrix) Transpose() Matrix {
+	var result Matrix
+	for row := 0; row < 3; row++ {
+		for col := 0; col < 3; col++ {
+			result[row][col] = m[col][row]
+		}
+	}
+	return result
+}
+
+// allRotations finds every rotation that can be built from quarter turns
+// about the axes
+func allRotations() []Matrix {
+	result := []Matrix{Identity}
+	seen := map[Matrix]bool{Identity: true}
+
+	for i := 0; i < len(result); i++ {
+		for _, turn := range []Matrix{RotateX, RotateY, RotateZ} {
+			next := turn.Mul(result[i])
+			if !seen[next] {
+				seen[next] = true
+				result = append(result, next)
+			}
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,58 @@
+package geom
+
+import "testing"
+
+func TestQuarterTurns(t *testing.T) {
+	p := Point3{4, 3, 2}
+
+	tests := map[string]struct {
+		turn     Matrix
+		expected []Point3
+	}{
+		"X": {RotateX, []Point3{{4, 3, 2}, {4, -2, 3}, {4, -3, -2}, {4, 2, -3}}},
+		"Y": {RotateY, []Point3{{4, 3, 2}, {2, 3, -4}, {-4, 3, -2}, {-2, 3, 4}}},
+		"Z": {RotateZ, []Point3{{4, 3, 2}, {-3, 4, 2}, {-4, -3, 2}, {3, -4, 2}}},
+	}
+
+	for axis, test := range tests {
+		m := Identity
+		for i, expected := range test.expected {
+			if actual := m.Apply(p); actual != expected {
+				t.Errorf("%s %d degrees: expected %v, got %v", axis, 90*i, expected, actual)
+			}
+			m = test.turn.Mul(m)
+		}
+
+		if m != Identity {
+			t.Errorf("%s: four quarter turns should be the identity, got %v", axis, m)
+		}
+	}
+}
+
+func TestRotations(t *testing.T) {
+	if len(Rotations) != 24 {
+		t.Fatalf("Expected 24 rotations, got %d", len(Rotations))
+	}
+
+	if Rotations[0] != Identity {
+		t.Errorf("Expected the first rotation to be the identity")
+	}
+
+	seen := make(map[Point3]bool)
+	p := Point3{1, 2, 3}
+
+	for _, r := range Rotations {
+		rotated := r.Apply(p)
+		seen[rotated] = true
+
+		if back := r.Transpose().Apply(rotated); back != p {
+			t.Errorf("Transpose of %v did not undo it: got %v", r, back)
+		}
+	}
+
+	// A point with distinct coordinates ends up somewhere different for every
+	// rotation
+	if len(seen) != 24 {
+		t.Errorf("Expected 24 distinct points, got %d", len(seen))
+	}
+}
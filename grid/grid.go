@@ -0,0 +1,313 @@
+// Package grid provides a rectangular, two-dimensional grid of integers along
+// with the parsing, neighbor lookup and rendering that grid-based puzzles
+// tend to need.
+package grid
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/matthinz/aoc-golang/geom"
+)
+
+// Point is a position within a Grid. X increases to the right and Y
+// increases downward.
+type Point = geom.Point
+
+// Grid is a rectangular grid of int cells. Puzzles that deal in characters
+// store each character's rune value.
+type Grid struct {
+	width  int
+	height int
+	cells  []int
+
+	// whether points off one edge wrap around to the opposite edge
+	wrap bool
+}
+
+// Offsets to the four orthogonal neighbors of a point: up, right, down and
+// left
+var Orthogonal = []Point{{X: 0, Y: -1}, {X: 1, Y: 0}, {X: 0, Y: 1}, {X: -1, Y: 0}}
+
+// Offsets to all eight neighbors of a point, including diagonals, in reading
+// order
+var Surrounding = []Point{
+	{X: -1, Y: -1}, {X: 0, Y: -1}, {X: 1, Y: -1},
+	{X: -1, Y: 0}, {X: 1, Y: 0},
+	{X: -1, Y: 1}, {X: 0, Y: 1}, {X: 1, Y: 1},
+}
+
+// New returns a width x height grid with every cell set to 0
+func New(width, height int) *Grid {
+	return &Grid{
+		width:  width,
+		height: height,
+		cells:  make([]int, width*height),
+	}
+}
+
+// Filled returns a width x height grid with every cell set to value
+func Filled(width, height, value int) *Grid {
+	g := New(width, height)
+	for i := range g.cells {
+		g.cells[i] = value
+	}
+	return g
+}
+
+// FromRows returns a grid holding a copy of rows, which must all be the same
+// length.
+func FromRows(rows [][]int) *Grid {
+	if len(rows) == 0 {
+		return New(0, 0)
+	}
+
+	g := New(len(rows[0]), len(rows))
+
+	for y, row := range rows {
+		if len(row) != g.width {
+			panic("grid: rows are not all the same length")
+		}
+		copy(g.cells[y*g.width:], row)
+	}
+
+	return g
+}
+
+// Width returns the number of columns in g
+func (g *Grid) Width() int {
+	return g.width
+}
+
+// Height returns the number of rows in g
+func (g *Grid) Height() int {
+	return g.height
+}
+
+// Wrapping returns whether g wraps around at its edges
+func (g *Grid) Wrapping() bool {
+	return g.wrap
+}
+
+// SetWrapping controls whether g behaves like a torus, with points that fall
+// off one edge continuing from the opposite edge.
+func (g *Grid) SetWrapping(wrap bool) {
+	g.wrap = wrap
+}
+
+// Resolve returns the cell that p refers to, wrapping it around if g wraps.
+// The second value reports whether p refers to a cell at all.
+func (g *Grid) Resolve(p Point) (Point, bool) {
+	if g.wrap && g.width > 0 && g.height > 0 {
+		p.X = ((p.X % g.width) + g.width) % g.width
+		p.Y = ((p.Y % g.height) + g.height) % g.height
+		return p, true
+	}
+	return p, g.InBounds(p)
+}
+
+// InBounds returns whether p lies within g, without any wrapping
+func (g *Grid) InBounds(p Point) bool {
+	return p.X >= 0 && p.X < g.width && p.Y >= 0 && p.Y < g.height
+}
+
+// Get returns the value at p. It panics if p is out of bounds and g does not
+// wrap.
+func (g *Grid) Get(p Point) int {
+	v, ok := g.Lookup(p)
+	if !ok {
+		panic("grid: point out of bounds: " + p.String())
+	}
+	return v
+}
+
+// Lookup returns the value at p + a flag indicating whether p was in bounds
+func (g *Grid) Lookup(p Point) (int, bool) {
+	p, ok := g.Resolve(p)
+	if !ok {
+		return 0, false
+	}
+	return g.cells[p.Y*g.width+p.X], true
+}
+
+// Set changes the value at p. It panics if p is out of bounds and g does not
+// wrap.
+func (g *Grid) Set(p Point, value int) {
+	p, ok := g.Resolve(p)
+	if !ok {
+		panic("grid: point out of bounds: " + p.String())
+	}
+	g.cells[p.Y*g.width+p.X] = value
+}
+
+// Points returns every point in g in reading order (left to right, top to
+// bottom)
+func (g *Grid) Points() []Point {
+	result := make([]Point, 0, len(g.cells))
+	for y := 0; y < g.height; y++ {
+		for x := 0; x < g.width; x++ {
+			result = append(result, Point{X: x, Y: y})
+		}
+	}
+	return result
+}
+
+// Each calls fn for every cell in g, in reading order
+func (g *Grid) Each(fn func(p Point, value int)) {
+	for i, v := range g.cells {
+		fn(Point{X: i % g.width, Y: i / g.width}, v)
+	}
+}
+
+// Count returns the number of cells for which fn returns true
+func (g *Grid) Count(fn func(value int) bool) int {
+	count := 0
+	for _, v := range g.cells {
+		if fn(v) {
+			count++
+		}
+	}
+	return count
+}
+
+// Neighbors returns the cells reached by adding each of offsets to p,
+// skipping any that fall outside g. If g wraps, nothing is skipped.
+func (g *Grid) Neighbors(p Point, offsets []Point) []Point {
+	result := make([]Point, 0, len(offsets))
+	for _, o := range offsets {
+		if n, ok := g.Resolve(p.Add(o)); ok {
+			result = append(result, n)
+		}
+	}
+	return result
+}
+
+// Neighbors4 returns the orthogonal neighbors of p
+func (g *Grid) Neighbors4(p Point) []Point {
+	return g.Neighbors(p, Orthogonal)
+}
+
+// Neighbors8 returns the orthogonal and diagonal neighbors of p
+func (g *Grid) Neighbors8(p Point) []Point {
+	return g.Neighbors(p, Surrounding)
+}
+
+// FloodFill returns the region of connected cells containing start, moving
+// between cells using offsets (e.g. Orthogonal). Only cells for which include
+// returns true are part of the region; if start is not, the region is empty.
+// Points are returned in the order they were reached.
+func (g *Grid) FloodFill(start Point, offsets []Point, include func(p Point, value int) bool) []Point {
+	start, ok := g.Resolve(start)
+	if !ok || !include(start, g.Get(start)) {
+		return nil
+	}
+
+	seen := map[Point]bool{start: true}
+	region := []Point{start}
+
+	for i := 0; i < len(region); i++ {
+		for _, n := range g.Neighbors(region[i], offsets) {
+			if seen[n] {
+				continue
+			}
+			seen[n] = true
+			if include(n, g.Get(n)) {
+				region = append(region, n)
+			}
+		}
+	}
+
+	return region
+}
+
+// Clone returns a copy of g
+func (g *Grid) Clone() *Grid {
+	result := *g
+	result.cells = make([]int, len(g.cells))
+	copy(result.cells, g.cells)
+	return &result
+}
+
+// Equal returns whether g and other are the same size with the same values
+func (g *Grid) Equal(other *Grid) bool {
+	if g.width != other.width || g.height != other.height {
+		return false
+	}
+	for i := range g.cells {
+		if g.cells[i] != other.cells[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Rows returns a copy of g's values as a slice of rows
+func (g *Grid) Rows() [][]int {
+	result := make([][]int, g.height)
+	for y := range result {
+		result[y] = make([]int, g.width)
+		copy(result[y], g.cells[y*g.width:(y+1)*g.width])
+	}
+	return result
+}
+
+// Tile returns a grid made of timesX x timesY copies of g, laid side by side.
+// fn computes the value of each cell from the corresponding value in g and
+// the position of the tile it is in (the top-left tile is 0, 0).
+func (g *Grid) Tile(timesX, timesY int, fn func(value, tileX, tileY int) int) *Grid {
+	result := New(g.width*timesX, g.height*timesY)
+	result.wrap = g.wrap
+
+	for tileY := 0; tileY < timesY; tileY++ {
+		for tileX := 0; tileX < timesX; tileX++ {
+			g.Each(func(p Point, value int) {
+				dest := Point{X: tileX*g.width + p.X, Y: tileY*g.height + p.Y}
+				result.Set(dest, fn(value, tileX, tileY))
+			})
+		}
+	}
+
+	return result
+}
+
+// Expand returns a copy of g with n extra cells on every side, set to fill
+func (g *Grid) Expand(n int, fill int) *Grid {
+	result := Filled(g.width+2*n, g.height+2*n, fill)
+	result.wrap = g.wrap
+
+	g.Each(func(p Point, value int) {
+		result.Set(Point{X: p.X + n, Y: p.Y + n}, value)
+	})
+
+	return result
+}
+
+// Render returns a textual version of g with one line per row, using cell to
+// render each value. Lines are separated by newlines; there is no trailing
+// newline.
+func (g *Grid) Render(cell func(value int) string) string {
+	var b strings.Builder
+	for y := 0; y < g.height; y++ {
+		if y > 0 {
+			b.WriteString("\n")
+		}
+		for x := 0; x < g.width; x++ {
+			b.WriteString(cell(g.cells[y*g.width+x]))
+		}
+	}
+	return b.String()
+}
+
+// RenderRunes renders a grid whose values are runes, e.g. one returned by
+// ParseRunes
+func (g *Grid) RenderRunes() string {
+	return g.Render(func(value int) string {
+		return string(rune(value))
+	})
+}
+
+// String renders g with each value written out in decimal, which suits grids
+// of digits
+func (g *Grid) String() string {
+	return g.Render(strconv.Itoa)
+}
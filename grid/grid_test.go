@@ -0,0 +1,199 @@
+package grid
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestGetAndSet(t *testing.T) {
+	g := New(3, 2)
+	g.Set(Point{X: 2, Y: 1}, 7)
+
+	if v := g.Get(Point{X: 2, Y: 1}); v != 7 {
+		t.Errorf("Expected 7, got %d", v)
+	}
+
+	if _, ok := g.Lookup(Point{X: 3, Y: 1}); ok {
+		t.Errorf("3,1 should be out of bounds")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Expected Get to panic when out of bounds")
+		}
+	}()
+	g.Get(Point{X: -1, Y: 0})
+}
+
+func TestWrapping(t *testing.T) {
+	g := FromRows([][]int{
+		{1, 2, 3},
+		{4, 5, 6},
+	})
+	g.SetWrapping(true)
+
+	tests := map[Point]int{
+		{X: 3, Y: 0}:   1,
+		{X: -1, Y: 0}:  3,
+		{X: 0, Y: 2}:   1,
+		{X: 0, Y: -1}:  4,
+		{X: -4, Y: -3}: 6,
+	}
+
+	for p, expected := range tests {
+		if actual := g.Get(p); actual != expected {
+			t.Errorf("%v: expected %d, got %d", p, expected, actual)
+		}
+	}
+
+	if n := g.Neighbors8(Point{X: 0, Y: 0}); len(n) != 8 {
+		t.Errorf("Expected 8 neighbors when wrapping, got %v", n)
+	}
+}
+
+func TestNeighbors(t *testing.T) {
+	g := New(3, 3)
+
+	tests := []struct {
+		p     Point
+		four  int
+		eight int
+	}{
+		{Point{X: 0, Y: 0}, 2, 3},
+		{Point{X: 1, Y: 0}, 3, 5},
+		{Point{X: 1, Y: 1}, 4, 8},
+	}
+
+	for _, test := range tests {
+		if n := g.Neighbors4(test.p); len(n) != test.four {
+			t.Errorf("%v: expected %d orthogonal neighbors, got %v", test.p, test.four, n)
+		}
+		if n := g.Neighbors8(test.p); len(n) != test.eight {
+			t.Errorf("%v: expected %d surrounding neighbors, got %v", test.p, test.eight, n)
+		}
+	}
+}
+
+func TestFloodFill(t *testing.T) {
+	g, err := ParseDigits(strings.NewReader(`
+2199943210
+3987894921
+9856789892
+8767896789
+9899965678
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	below9 := func(p Point, v int) bool { return v < 9 }
+
+	region := g.FloodFill(Point{X: 9, Y: 0}, Orthogonal, below9)
+	if len(region) != 9 {
+		t.Errorf("Expected a region of 9, got %d: %v", len(region), region)
+	}
+
+	region = g.FloodFill(Point{X: 2, Y: 2}, Orthogonal, below9)
+	if len(region) != 14 {
+		t.Errorf("Expected a region of 14, got %d: %v", len(region), region)
+	}
+
+	if region := g.FloodFill(Point{X: 2, Y: 0}, Orthogonal, below9); region != nil {
+		t.Errorf("Expected no region when starting on a 9, got %v", region)
+	}
+}
+
+func TestTile(t *testing.T) {
+	g := FromRows([][]int{{1, 2}})
+
+	tiled := g.Tile(2, 2, func(v, tileX, tileY int) int {
+		return v + 10*tileX + 100*tileY
+	})
+
+	expected := [][]int{
+		{1, 2, 11, 12},
+		{101, 102, 111, 112},
+	}
+
+	if actual := tiled.Rows(); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Expected %v, got %v", expected, actual)
+	}
+}
+
+func TestExpand(t *testing.T) {
+	g := FromRows([][]int{{1}})
+
+	expanded := g.Expand(1, 0)
+
+	expected := "000\n010\n000"
+	if actual := expanded.String(); actual != expected {
+		t.Errorf("Expected %q, got %q", expected, actual)
+	}
+}
+
+func TestCloneAndEqual(t *testing.T) {
+	g := FromRows([][]int{{1, 2}, {3, 4}})
+	c := g.Clone()
+
+	if !g.Equal(c) {
+		t.Errorf("Clone should be equal to the original")
+	}
+
+	c.Set(Point{X: 0, Y: 0}, 9)
+
+	if g.Equal(c) {
+		t.Errorf("Changing the clone should not change the original")
+	}
+
+	if g.Get(Point{X: 0, Y: 0}) != 1 {
+		t.Errorf("Original was modified")
+	}
+}
+
+func TestEachAndCount(t *testing.T) {
+	g := FromRows([][]int{{1, 2}, {3, 4}})
+
+	var points []Point
+	sum := 0
+	g.Each(func(p Point, v int) {
+		points = append(points, p)
+		sum += v
+	})
+
+	if !reflect.DeepEqual(points, g.Points()) {
+		t.Errorf("Each should visit points in the same order as Points: %v", points)
+	}
+
+	sorted := sort.SliceIsSorted(points, func(i, j int) bool {
+		if points[i].Y == points[j].Y {
+			return points[i].X < points[j].X
+		}
+		return points[i].Y < points[j].Y
+	})
+	if !sorted {
+		t.Errorf("Points should be in reading order: %v", points)
+	}
+
+	if sum != 10 {
+		t.Errorf("Expected a sum of 10, got %d", sum)
+	}
+
+	if n := g.Count(func(v int) bool { return v%2 == 0 }); n != 2 {
+		t.Errorf("Expected 2 even values, got %d", n)
+	}
+}
+
+func TestRenderRunes(t *testing.T) {
+	input := "#.\n.#"
+
+	g, err := ParseRunes(strings.NewReader(input), "#.")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if actual := g.RenderRunes(); actual != input {
+		t.Errorf("Expected %q, got %q", input, actual)
+	}
+}
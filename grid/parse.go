@@ -0,0 +1,71 @@
+package grid
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/matthinz/aoc-golang/parse"
+)
+
+// Parse reads a grid with one row per line of text, calling cell to convert
+// each character into a value. Blank lines are skipped, surrounding
+// whitespace is ignored and every line must be the same length. Problems are
+// reported as *parse.Errors.
+func Parse(r io.Reader, cell func(c rune) (int, error)) (*Grid, error) {
+	lines, err := parse.Lines(r)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([][]int, 0, len(lines))
+	width := -1
+
+	for _, line := range lines {
+		start := len(line.Text) - len(strings.TrimLeft(line.Text, " \t"))
+		text := strings.TrimSpace(line.Text)
+
+		row := make([]int, 0, len(text))
+		for i, c := range text {
+			value, err := cell(c)
+			if err != nil {
+				return nil, line.Errorf(start+i, "%v", err)
+			}
+			row = append(row, value)
+		}
+
+		if width < 0 {
+			width = len(row)
+		} else if len(row) != width {
+			return nil, line.Errorf(start, "expected %d characters, found %d", width, len(row))
+		}
+
+		rows = append(rows, row)
+	}
+
+	return FromRows(rows), nil
+}
+
+// ParseDigits reads a grid of single decimal digits, e.g.
+//
+//	2199943210
+//	3987894921
+func ParseDigits(r io.Reader) (*Grid, error) {
+	return Parse(r, func(c rune) (int, error) {
+		if c < '0' || c > '9' {
+			return 0, fmt.Errorf("invalid digit %q", c)
+		}
+		return int(c - '0'), nil
+	})
+}
+
+// ParseRunes reads a grid of characters, storing each character's rune value.
+// If allowed is not empty, any character not in it is an error.
+func ParseRunes(r io.Reader, allowed string) (*Grid, error) {
+	return Parse(r, func(c rune) (int, error) {
+		if allowed != "" && !strings.ContainsRune(allowed, c) {
+			return 0, fmt.Errorf("invalid character %q", c)
+		}
+		return int(c), nil
+	})
+}
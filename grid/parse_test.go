@@ -0,0 +1,64 @@
+package grid
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/matthinz/aoc-golang/parse"
+)
+
+func TestParseDigits(t *testing.T) {
+	g, err := ParseDigits(strings.NewReader("\n123\n456\n\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if g.Width() != 3 || g.Height() != 2 {
+		t.Fatalf("Expected a 3x2 grid, got %dx%d", g.Width(), g.Height())
+	}
+
+	if g.String() != "123\n456" {
+		t.Errorf("Wrong grid: %q", g.String())
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		input  string
+		line   int
+		column int
+	}{
+		{"123\n4x6", 2, 2},
+		{"123\n\n45", 3, 1},
+	}
+
+	for _, test := range tests {
+		_, err := ParseDigits(strings.NewReader(test.input))
+
+		var parseErr *parse.Error
+		if !errors.As(err, &parseErr) {
+			t.Errorf("%q: expected a *parse.Error, got %v", test.input, err)
+			continue
+		}
+
+		if parseErr.Line != test.line || parseErr.Column != test.column {
+			t.Errorf("%q: expected an error at %d:%d, got %v", test.input, test.line, test.column, err)
+		}
+	}
+}
+
+func TestParseRunesAllowed(t *testing.T) {
+	if _, err := ParseRunes(strings.NewReader("#.\n.x"), "#."); err == nil {
+		t.Errorf("Expected an error for a character that isn't allowed")
+	}
+
+	g, err := ParseRunes(strings.NewReader("ab\ncd"), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if v := g.Get(Point{X: 1, Y: 1}); v != 'd' {
+		t.Errorf("Expected 'd', got %q", rune(v))
+	}
+}
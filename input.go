@@ -0,0 +1,212 @@
+package aoc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultInputURL is where the HTTP input provider downloads inputs from
+// unless told otherwise.
+const DefaultInputURL = "https://adventofcode.com"
+
+// ErrNoInput is returned by InputProviders that do not have input for the
+// requested day.
+var ErrNoInput = errors.New("no input available")
+
+// InputProvider supplies the puzzle input for a given year and day.
+type InputProvider interface {
+	Input(ctx context.Context, year, day int) (string, error)
+}
+
+// InputProviderFunc adapts an ordinary function into an InputProvider.
+type InputProviderFunc func(ctx context.Context, year, day int) (string, error)
+
+// embeddedInputProvider returns the input compiled into each registered day
+type embeddedInputProvider struct{}
+
+// fileInputProvider returns the contents of a single file for every day
+type fileInputProvider struct {
+	name string
+}
+
+// readerInputProvider returns everything read from r for every day. r is only
+// read once.
+type readerInputProvider struct {
+	r    io.Reader
+	once sync.Once
+	data string
+	err  error
+}
+
+// cacheInputProvider reads inputs from a directory laid out like this
+// repository, i.e. <dir>/<year>/<day>/input. Inputs missing from the
+// directory are requested from fallback (if there is one) and saved.
+type cacheInputProvider struct {
+	dir      string
+	fallback InputProvider
+	mutex    sync.Mutex
+}
+
+// httpInputProvider downloads inputs from the Advent of Code website (or a
+// stand-in for it).
+type httpInputProvider struct {
+	baseURL string
+	session string
+	client  *http.Client
+}
+
+func (f InputProviderFunc) Input(ctx context.Context, year, day int) (string, error) {
+	return f(ctx, year, day)
+}
+
+// NewEmbeddedInputProvider returns an InputProvider that supplies the default
+// input embedded in each registered Day.
+func NewEmbeddedInputProvider() InputProvider {
+	return embeddedInputProvider{}
+}
+
+// NewFileInputProvider returns an InputProvider that supplies the contents of
+// the named file, whatever the day.
+func NewFileInputProvider(name string) InputProvider {
+	return &fileInputProvider{name}
+}
+
+// NewReaderInputProvider returns an InputProvider that supplies everything
+// read from r, whatever the day. r is read in full the first time input is
+// requested.
+func NewReaderInputProvider(r io.Reader) InputProvider {
+	return &readerInputProvider{r: r}
+}
+
+// NewStdinInputProvider returns an InputProvider that supplies whatever is
+// read from standard input.
+func NewStdinInputProvider() InputProvider {
+	return NewReaderInputProvider(os.Stdin)
+}
+
+// NewCacheInputProvider returns an InputProvider that reads inputs from dir,
+// which is laid out like this repository: the input for 2021 day 5 is read
+// from <dir>/2021/05/input. Inputs not found there are requested from
+// fallback and written to dir for next time. If fallback is nil, ErrNoInput
+// is returned for them instead.
+func NewCacheInputProvider(dir string, fallback InputProvider) InputProvider {
+	return &cacheInputProvider{dir: dir, fallback: fallback}
+}
+
+// NewHTTPInputProvider returns an InputProvider that downloads inputs from
+// baseURL (normally DefaultInputURL), authenticating with the given session
+// cookie. If client is nil, http.DefaultClient is used.
+func NewHTTPInputProvider(baseURL, session string, client *http.Client) InputProvider {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &httpInputProvider{strings.TrimRight(baseURL, "/"), session, client}
+}
+
+func (p embeddedInputProvider) Input(ctx context.Context, year, day int) (string, error) {
+	y, found := LookupYear(year)
+	if !found {
+		return "", fmt.Errorf("%d day %d: %w", year, day, ErrNoInput)
+	}
+
+	d, found := y.Day(day)
+	if !found {
+		return "", fmt.Errorf("%d day %d: %w", year, day, ErrNoInput)
+	}
+
+	return d.DefaultInput(), nil
+}
+
+func (p *fileInputProvider) Input(ctx context.Context, year, day int) (string, error) {
+	data, err := os.ReadFile(p.name)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+func (p *readerInputProvider) Input(ctx context.Context, year, day int) (string, error) {
+	p.once.Do(func() {
+		var data []byte
+		data, p.err = io.ReadAll(p.r)
+		p.data = string(data)
+	})
+	return p.data, p.err
+}
+
+func (p *cacheInputProvider) Input(ctx context.Context, year, day int) (string, error) {
+	// Hold the lock throughout so that concurrent requests for the same day
+	// only fetch it once
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	file := filepath.Join(p.dir, strconv.Itoa(year), fmt.Sprintf("%02d", day), "input")
+
+	data, err := os.ReadFile(file)
+	if err == nil {
+		return string(data), nil
+	}
+
+	if !errors.Is(err, os.ErrNotExist) {
+		return "", err
+	}
+
+	if p.fallback == nil {
+		return "", fmt.Errorf("%s: %w", file, ErrNoInput)
+	}
+
+	input, err := p.fallback.Input(ctx, year, day)
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		return "", err
+	}
+
+	if err := os.WriteFile(file, []byte(input), 0644); err != nil {
+		return "", err
+	}
+
+	return input, nil
+}
+
+func (p *httpInputProvider) Input(ctx context.Context, year, day int) (string, error) {
+	url := fmt.Sprintf("%s/%d/day/%d/input", p.baseURL, year, day)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+
+	req.AddCookie(&http.Cookie{Name: "session", Value: p.session})
+	req.Header.Set("User-Agent", "github.com/matthinz/aoc-golang")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return "", fmt.Errorf("%s: %w", url, ErrNoInput)
+	case resp.StatusCode != http.StatusOK:
+		return "", fmt.Errorf("%s: %s: %s", url, resp.Status, strings.TrimSpace(string(data)))
+	}
+
+	return string(data), nil
+}
@@ -0,0 +1,153 @@
+package aoc
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestEmbeddedInputProvider(t *testing.T) {
+	// Use a year that real puzzles will never be registered for
+	const year = 4
+
+	Register(year, NewDay(1, "embedded input"))
+
+	p := NewEmbeddedInputProvider()
+
+	input, err := p.Input(context.Background(), year, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if input != "embedded input" {
+		t.Errorf("Wrong input: %q", input)
+	}
+
+	if _, err := p.Input(context.Background(), year, 2); !errors.Is(err, ErrNoInput) {
+		t.Errorf("Expected ErrNoInput for a missing day, got %v", err)
+	}
+}
+
+func TestFileInputProvider(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "input")
+	if err := os.WriteFile(file, []byte("from a file"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	input, err := NewFileInputProvider(file).Input(context.Background(), 2021, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if input != "from a file" {
+		t.Errorf("Wrong input: %q", input)
+	}
+}
+
+func TestReaderInputProviderReadsOnce(t *testing.T) {
+	p := NewReaderInputProvider(strings.NewReader("abc"))
+
+	for day := 1; day <= 2; day++ {
+		input, err := p.Input(context.Background(), 2021, day)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if input != "abc" {
+			t.Errorf("Day %d: wrong input: %q", day, input)
+		}
+	}
+}
+
+func TestCacheInputProvider(t *testing.T) {
+	dir := t.TempDir()
+
+	cached := filepath.Join(dir, "2021", "05", "input")
+	if err := os.MkdirAll(filepath.Dir(cached), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(cached, []byte("cached"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	fetched := 0
+	fallback := InputProviderFunc(func(ctx context.Context, year, day int) (string, error) {
+		fetched++
+		return "fetched", nil
+	})
+
+	p := NewCacheInputProvider(dir, fallback)
+
+	input, err := p.Input(context.Background(), 2021, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if input != "cached" || fetched != 0 {
+		t.Errorf("Expected the cached input to be used, got %q (%d fetches)", input, fetched)
+	}
+
+	for i := 0; i < 2; i++ {
+		input, err = p.Input(context.Background(), 2021, 6)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if input != "fetched" {
+			t.Errorf("Expected the fetched input, got %q", input)
+		}
+	}
+
+	if fetched != 1 {
+		t.Errorf("Expected the input to be fetched once, got %d", fetched)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "2021", "06", "input"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "fetched" {
+		t.Errorf("Fetched input was not cached: %q", data)
+	}
+
+	if _, err := NewCacheInputProvider(dir, nil).Input(context.Background(), 2021, 7); !errors.Is(err, ErrNoInput) {
+		t.Errorf("Expected ErrNoInput without a fallback, got %v", err)
+	}
+}
+
+func TestHTTPInputProvider(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cookie, err := r.Cookie("session")
+		if err != nil || cookie.Value != "s3cret" {
+			http.Error(w, "log in first", http.StatusBadRequest)
+			return
+		}
+
+		switch r.URL.Path {
+		case "/2021/day/5/input":
+			w.Write([]byte("0,9 -> 5,9\n"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	p := NewHTTPInputProvider(server.URL+"/", "s3cret", server.Client())
+
+	input, err := p.Input(context.Background(), 2021, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if input != "0,9 -> 5,9\n" {
+		t.Errorf("Wrong input: %q", input)
+	}
+
+	if _, err := p.Input(context.Background(), 2021, 26); !errors.Is(err, ErrNoInput) {
+		t.Errorf("Expected ErrNoInput for a missing day, got %v", err)
+	}
+
+	bad := NewHTTPInputProvider(server.URL, "wrong", server.Client())
+	if _, err := bad.Input(context.Background(), 2021, 5); err == nil || !strings.Contains(err.Error(), "log in first") {
+		t.Errorf("Expected the server's error to be reported, got %v", err)
+	}
+}
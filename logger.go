@@ -0,0 +1,107 @@
+package aoc
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os"
+)
+
+// Level is how important a log message is. Loggers drop messages less
+// important than their own level.
+type Level int
+
+const (
+	// LevelDebug is for detail that's only interesting when digging into how
+	// a puzzle is being solved, e.g. every step of a search
+	LevelDebug Level = iota
+
+	// LevelInfo is for a running commentary on the solution, e.g. what was
+	// parsed and how big the problem turned out to be
+	LevelInfo
+
+	// LevelWarn is for anything that looks wrong, but not wrong enough to give
+	// up on the puzzle
+	LevelWarn
+)
+
+// Logger is what puzzlers use to describe what they're doing. It works like a
+// log.Logger, but each message has a Level and messages below the logger's
+// level are dropped.
+//
+// Printf logs at LevelInfo, so code written against log.Logger keeps working.
+type Logger struct {
+	l     *log.Logger
+	level Level
+}
+
+// NewLogger returns a Logger that writes messages of at least level to w. The
+// prefix and flags are as for log.New.
+func NewLogger(w io.Writer, prefix string, flags int, level Level) *Logger {
+	return &Logger{log.New(w, prefix, flags), level}
+}
+
+// DefaultLogger returns a Logger that writes messages of LevelInfo and above
+// to stderr, like log.Default
+func DefaultLogger() *Logger {
+	return NewLogger(os.Stderr, "", log.LstdFlags, LevelInfo)
+}
+
+// DiscardLogger returns a Logger that drops everything
+func DiscardLogger() *Logger {
+	return NewLogger(io.Discard, "", 0, LevelWarn+1)
+}
+
+// Enabled returns whether messages at level are written. Puzzlers can use it
+// to skip building expensive debug output that would only be dropped.
+func (l *Logger) Enabled(level Level) bool {
+	return level >= l.level
+}
+
+func (l *Logger) Debugf(format string, args ...interface{}) {
+	l.logf(LevelDebug, format, args...)
+}
+
+func (l *Logger) Infof(format string, args ...interface{}) {
+	l.logf(LevelInfo, format, args...)
+}
+
+func (l *Logger) Warnf(format string, args ...interface{}) {
+	l.logf(LevelWarn, format, args...)
+}
+
+// Printf is the same as Infof
+func (l *Logger) Printf(format string, args ...interface{}) {
+	l.logf(LevelInfo, format, args...)
+}
+
+// Writer returns where l writes its messages
+func (l *Logger) Writer() io.Writer {
+	return l.l.Writer()
+}
+
+func (l *Logger) logf(level Level, format string, args ...interface{}) {
+	if !l.Enabled(level) {
+		return
+	}
+
+	tag := ""
+	if level != LevelInfo {
+		tag = level.String() + ": "
+	}
+
+	l.l.Output(3, tag+fmt.Sprintf(format, args...))
+}
+
+func (level Level) String() string {
+	switch level {
+	case LevelDebug:
+		return "debug"
+	case LevelInfo:
+		return "info"
+	case LevelWarn:
+		return "warning"
+	default:
+		return fmt.Sprintf("level %d", int(level))
+	}
+}
@@ -0,0 +1,49 @@
+package aoc
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLoggerLevels(t *testing.T) {
+	tests := []struct {
+		level    Level
+		expected string
+	}{
+		{LevelDebug, "debug: one\ntwo\nthree\nwarning: four\n"},
+		{LevelInfo, "two\nthree\nwarning: four\n"},
+		{LevelWarn, "warning: four\n"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.level.String(), func(t *testing.T) {
+			var b strings.Builder
+			l := NewLogger(&b, "", 0, test.level)
+
+			l.Debugf("one")
+			l.Infof("two")
+			l.Printf("three")
+			l.Warnf("four")
+
+			if b.String() != test.expected {
+				t.Errorf("Expected %q, got %q", test.expected, b.String())
+			}
+		})
+	}
+}
+
+func TestLoggerEnabled(t *testing.T) {
+	l := NewLogger(nil, "", 0, LevelInfo)
+
+	if l.Enabled(LevelDebug) {
+		t.Errorf("Debug messages should not be enabled at LevelInfo")
+	}
+
+	if !l.Enabled(LevelInfo) || !l.Enabled(LevelWarn) {
+		t.Errorf("Info and warning messages should be enabled at LevelInfo")
+	}
+
+	if DiscardLogger().Enabled(LevelWarn) {
+		t.Errorf("DiscardLogger should not enable anything")
+	}
+}
@@ -0,0 +1,74 @@
+package aoc
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+)
+
+// Options are settings for a puzzler beyond its input, by name, e.g. which
+// rule to play a game by or what to report on along the way. Each puzzler
+// documents the options it understands and ignores any others. Puzzlers get
+// theirs from their context using OptionsFrom.
+type Options map[string]string
+
+type optionsKey struct{}
+
+// WithOptions returns a copy of ctx that carries o, for a puzzler to read.
+func WithOptions(ctx context.Context, o Options) context.Context {
+	return context.WithValue(ctx, optionsKey{}, o)
+}
+
+// OptionsFrom returns the Options carried by ctx. If there aren't any, the
+// Options returned are empty, so puzzlers can always look options up without
+// checking first.
+func OptionsFrom(ctx context.Context) Options {
+	o, _ := ctx.Value(optionsKey{}).(Options)
+	return o
+}
+
+// Lookup returns the value of the named option and whether it was set
+func (o Options) Lookup(name string) (string, bool) {
+	value, found := o[name]
+	return value, found
+}
+
+// Get returns the value of the named option, or fallback if it wasn't set
+func (o Options) Get(name string, fallback string) string {
+	if value, found := o[name]; found {
+		return value
+	}
+	return fallback
+}
+
+// Int returns the value of the named option as an integer, or fallback if it
+// wasn't set. A value that isn't an integer is an error.
+func (o Options) Int(name string, fallback int) (int, error) {
+	value, found := o[name]
+	if !found {
+		return fallback, nil
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("option %s: invalid number %q", name, value)
+	}
+
+	return n, nil
+}
+
+// Bool returns whether the named option is set to true, e.g. "true" or "1".
+// An option that isn't set is false, and one that isn't a boolean is an error.
+func (o Options) Bool(name string) (bool, error) {
+	value, found := o[name]
+	if !found {
+		return false, nil
+	}
+
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("option %s: invalid boolean %q", name, value)
+	}
+
+	return b, nil
+}
@@ -0,0 +1,51 @@
+package aoc
+
+import (
+	"context"
+	"testing"
+)
+
+func TestOptionsFrom(t *testing.T) {
+	// Looking up options when there aren't any is fine
+	o := OptionsFrom(context.Background())
+	if _, found := o.Lookup("rule"); found {
+		t.Errorf("Expected no options, got %v", o)
+	}
+	if value := o.Get("rule", "standard"); value != "standard" {
+		t.Errorf("Expected the fallback, got %q", value)
+	}
+
+	ctx := WithOptions(context.Background(), Options{"rule": "corners", "board": "3", "bad": "x"})
+	o = OptionsFrom(ctx)
+
+	if value := o.Get("rule", "standard"); value != "corners" {
+		t.Errorf("Expected %q, got %q", "corners", value)
+	}
+
+	if n, err := o.Int("board", 0); err != nil || n != 3 {
+		t.Errorf("Expected 3, got %d (%v)", n, err)
+	}
+
+	if n, err := o.Int("missing", 7); err != nil || n != 7 {
+		t.Errorf("Expected the fallback of 7, got %d (%v)", n, err)
+	}
+
+	if _, err := o.Int("bad", 0); err == nil || err.Error() != `option bad: invalid number "x"` {
+		t.Errorf("Wrong error: %v", err)
+	}
+
+	ctx = WithOptions(context.Background(), Options{"analyze": "true", "bad": "x"})
+	o = OptionsFrom(ctx)
+
+	if b, err := o.Bool("analyze"); err != nil || !b {
+		t.Errorf("Expected true, got %v (%v)", b, err)
+	}
+
+	if b, err := o.Bool("missing"); err != nil || b {
+		t.Errorf("Expected false, got %v (%v)", b, err)
+	}
+
+	if _, err := o.Bool("bad"); err == nil || err.Error() != `option bad: invalid boolean "x"` {
+		t.Errorf("Wrong error: %v", err)
+	}
+}
@@ -0,0 +1,122 @@
+package parse
+
+import (
+	"io"
+	"regexp"
+	"strings"
+)
+
+// KeyValue is a line made up of a key and a value, e.g. "name: value".
+type KeyValue struct {
+	Line  Line
+	Key   string
+	Value string
+}
+
+// Record holds the parts of a line captured by a regular expression.
+type Record struct {
+	Line Line
+
+	// Text captured by each of the expression's groups. Fields[0] is the
+	// first group, not the whole match.
+	Fields []string
+
+	// byte offset in Line.Text of each field, or -1 if it didn't participate
+	// in the match
+	offsets []int
+}
+
+// KeyValues reads a key and value from each non-blank line of r, split at the
+// first occurrence of sep (e.g. ":" or " -> "). Whitespace around keys and
+// values is removed.
+func KeyValues(r io.Reader, sep string) ([]KeyValue, error) {
+	lines, err := Lines(r)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]KeyValue, 0, len(lines))
+
+	for _, line := range lines {
+		kv, err := line.KeyValue(sep)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, kv)
+	}
+
+	return result, nil
+}
+
+// Records matches re against each non-blank line of r. Every line must match.
+func Records(r io.Reader, re *regexp.Regexp) ([]Record, error) {
+	lines, err := Lines(r)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]Record, 0, len(lines))
+
+	for _, line := range lines {
+		record, err := line.Match(re)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, record)
+	}
+
+	return result, nil
+}
+
+// KeyValue splits l into a key and value at the first occurrence of sep
+func (l Line) KeyValue(sep string) (KeyValue, error) {
+	i := strings.Index(l.Text, sep)
+	if i < 0 {
+		return KeyValue{}, l.Errorf(-1, "expected %q", sep)
+	}
+
+	key, keyOffset := trim(l.Text[:i])
+	if key == "" {
+		return KeyValue{}, l.Errorf(keyOffset, "expected a key before %q", sep)
+	}
+
+	value, _ := trim(l.Text[i+len(sep):])
+
+	return KeyValue{l, key, value}, nil
+}
+
+// Match returns the groups captured when re is matched against l. It is an
+// error for l not to match.
+func (l Line) Match(re *regexp.Regexp) (Record, error) {
+	m := re.FindStringSubmatchIndex(l.Text)
+	if m == nil {
+		return Record{}, l.Errorf(-1, "%q does not match %s", l.Text, re)
+	}
+
+	groups := len(m)/2 - 1
+	record := Record{
+		Line:    l,
+		Fields:  make([]string, groups),
+		offsets: make([]int, groups),
+	}
+
+	for i := 0; i < groups; i++ {
+		start, end := m[2*(i+1)], m[2*(i+1)+1]
+		record.offsets[i] = start
+		if start >= 0 {
+			record.Fields[i] = l.Text[start:end]
+		}
+	}
+
+	return record, nil
+}
+
+// Int parses field i of r as a decimal integer
+func (r Record) Int(i int) (int, error) {
+	return r.Line.intAt(r.Fields[i], r.offsets[i])
+}
+
+// Errorf returns an *Error pointing at field i of r
+func (r Record) Errorf(i int, format string, args ...interface{}) error {
+	return r.Line.Errorf(r.offsets[i], format, args...)
+}
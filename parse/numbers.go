@@ -0,0 +1,139 @@
+package parse
+
+import (
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Ints reads one integer per line from r, skipping blank lines.
+func Ints(r io.Reader) ([]int, error) {
+	lines, err := Lines(r)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]int, 0, len(lines))
+
+	for _, line := range lines {
+		value, err := line.Int()
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, value)
+	}
+
+	return result, nil
+}
+
+// EachInt calls fn with each integer read from r, one per line, skipping blank
+// lines. Unlike Ints it handles each value as soon as it is read, so input of
+// any length can be processed without holding on to it. It stops at the first
+// error, either from parsing or returned by fn.
+func EachInt(r io.Reader, fn func(value int) error) error {
+	return scan(r, func(line Line) error {
+		if line.IsBlank() {
+			return nil
+		}
+
+		value, err := line.Int()
+		if err != nil {
+			return err
+		}
+
+		return fn(value)
+	})
+}
+
+// CommaInts reads comma-separated integers from r, e.g. "3,4,3,1,2". The
+// numbers may be spread across several lines.
+func CommaInts(r io.Reader) ([]int, error) {
+	lines, err := Lines(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []int
+
+	for _, line := range lines {
+		values, err := line.CommaInts()
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, values...)
+	}
+
+	return result, nil
+}
+
+// Int parses l, ignoring surrounding whitespace, as a decimal integer
+func (l Line) Int() (int, error) {
+	text, offset := trim(l.Text)
+	return l.intAt(text, offset)
+}
+
+// CommaInts parses l as a list of comma-separated decimal integers
+func (l Line) CommaInts() ([]int, error) {
+	var result []int
+	offset := 0
+
+	for _, token := range strings.Split(l.Text, ",") {
+		text, start := trim(token)
+
+		value, err := l.intAt(text, offset+start)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, value)
+
+		offset += len(token) + 1
+	}
+
+	return result, nil
+}
+
+// Ints parses l as a list of decimal integers separated by whitespace, e.g.
+// "22 13  17 11"
+func (l Line) Ints() ([]int, error) {
+	var result []int
+	text := l.Text
+	offset := 0
+
+	for {
+		trimmed := strings.TrimLeft(text, " \t")
+		offset += len(text) - len(trimmed)
+		if trimmed == "" {
+			break
+		}
+
+		end := strings.IndexAny(trimmed, " \t")
+		if end < 0 {
+			end = len(trimmed)
+		}
+
+		value, err := l.intAt(trimmed[:end], offset)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, value)
+
+		text = trimmed[end:]
+		offset += end
+	}
+
+	return result, nil
+}
+
+// intAt parses text, which was found at offset in l, as an integer
+func (l Line) intAt(text string, offset int) (int, error) {
+	if text == "" {
+		return 0, l.Errorf(offset, "expected a number")
+	}
+
+	value, err := strconv.Atoi(text)
+	if err != nil {
+		return 0, l.Errorf(offset, "invalid number %q", text)
+	}
+
+	return value, nil
+}
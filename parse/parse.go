@@ -0,0 +1,139 @@
+// Package parse reads the line-oriented text that puzzle inputs are made of:
+// lists of integers, comma-separated numbers, blocks separated by blank
+// lines, "key: value" lines and records picked out with regular expressions.
+//
+// Anything that doesn't parse is reported as an *Error giving the line (and,
+// where it is known, the column) at which the problem was found.
+package parse
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// Error describes a problem found at a particular place in the input.
+type Error struct {
+	// 1-based line number
+	Line int
+
+	// 1-based column (counted in characters), or 0 if the problem is with the
+	// line as a whole
+	Column int
+
+	Err error
+}
+
+// Line is a single line of input.
+type Line struct {
+	// 1-based line number
+	Number int
+
+	// The line's text, without its line ending
+	Text string
+}
+
+// Lines returns the lines read from r, skipping any that are blank.
+func Lines(r io.Reader) ([]Line, error) {
+	var lines []Line
+
+	err := scan(r, func(line Line) error {
+		if !line.IsBlank() {
+			lines = append(lines, line)
+		}
+		return nil
+	})
+
+	return lines, err
+}
+
+// Blocks returns the groups of lines read from r that are separated by one or
+// more blank lines, e.g.
+//
+//	NNCB
+//
+//	CH -> B
+//	HH -> N
+//
+// is two blocks, the first holding one line and the second two.
+func Blocks(r io.Reader) ([][]Line, error) {
+	var blocks [][]Line
+	var block []Line
+
+	err := scan(r, func(line Line) error {
+		if !line.IsBlank() {
+			block = append(block, line)
+			return nil
+		}
+
+		if len(block) > 0 {
+			blocks = append(blocks, block)
+			block = nil
+		}
+		return nil
+	})
+
+	if len(block) > 0 {
+		blocks = append(blocks, block)
+	}
+
+	return blocks, err
+}
+
+// IsBlank returns whether l holds nothing but whitespace
+func (l Line) IsBlank() bool {
+	return strings.TrimSpace(l.Text) == ""
+}
+
+// Trimmed returns l's text without surrounding whitespace, along with the
+// byte offset in l.Text at which it starts, for passing to Errorf
+func (l Line) Trimmed() (string, int) {
+	return trim(l.Text)
+}
+
+// Errorf returns an *Error for l. offset is the byte offset into l.Text that
+// the problem was found at, or -1 if the problem is with the line as a whole.
+func (l Line) Errorf(offset int, format string, args ...interface{}) error {
+	column := 0
+	if offset >= 0 && offset <= len(l.Text) {
+		column = utf8.RuneCountInString(l.Text[:offset]) + 1
+	}
+	return &Error{l.Number, column, fmt.Errorf(format, args...)}
+}
+
+func (e *Error) Error() string {
+	if e.Column == 0 {
+		return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+	}
+	return fmt.Sprintf("line %d, column %d: %v", e.Line, e.Column, e.Err)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// scan calls fn for every line read from r, blank or not, stopping at the
+// first error fn returns
+func scan(r io.Reader, fn func(line Line) error) error {
+	s := bufio.NewScanner(r)
+	number := 0
+
+	for s.Scan() {
+		number++
+		if err := fn(Line{number, strings.TrimSuffix(s.Text(), "\r")}); err != nil {
+			return err
+		}
+	}
+
+	return s.Err()
+}
+
+// trim returns text without surrounding whitespace, along with the byte
+// offset in text at which the result starts
+func trim(text string) (string, int) {
+	trimmed := strings.TrimLeft(text, " \t")
+	offset := len(text) - len(trimmed)
+	return strings.TrimRight(trimmed, " \t"), offset
+}
@@ -0,0 +1,213 @@
+package parse
+
+import (
+	"errors"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestLines(t *testing.T) {
+	lines, err := Lines(strings.NewReader("\none\r\n  \ntwo\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []Line{{2, "one"}, {4, "two"}}
+	if !reflect.DeepEqual(lines, expected) {
+		t.Errorf("Expected %v, got %v", expected, lines)
+	}
+}
+
+func TestBlocks(t *testing.T) {
+	blocks, err := Blocks(strings.NewReader("\nNNCB\n\n\nCH -> B\nHH -> N\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := [][]Line{
+		{{2, "NNCB"}},
+		{{5, "CH -> B"}, {6, "HH -> N"}},
+	}
+	if !reflect.DeepEqual(blocks, expected) {
+		t.Errorf("Expected %v, got %v", expected, blocks)
+	}
+}
+
+func TestInts(t *testing.T) {
+	values, err := Ints(strings.NewReader("199\n 200 \n\n-3\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if expected := []int{199, 200, -3}; !reflect.DeepEqual(values, expected) {
+		t.Errorf("Expected %v, got %v", expected, values)
+	}
+}
+
+func TestEachInt(t *testing.T) {
+	var values []int
+	err := EachInt(strings.NewReader("199\n 200 \n\n-3\n"), func(value int) error {
+		values = append(values, value)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if expected := []int{199, 200, -3}; !reflect.DeepEqual(values, expected) {
+		t.Errorf("Expected %v, got %v", expected, values)
+	}
+
+	// stops at the first error, whether from fn or the input
+	stop := errors.New("stop")
+	calls := 0
+	err = EachInt(strings.NewReader("1\n2\n3\n"), func(value int) error {
+		calls++
+		return stop
+	})
+	if err != stop || calls != 1 {
+		t.Errorf("Expected to stop after 1 call with %v, got %d calls and %v", stop, calls, err)
+	}
+
+	calls = 0
+	err = EachInt(strings.NewReader("1\nx\n3\n"), func(value int) error {
+		calls++
+		return nil
+	})
+	var parseErr *Error
+	if !errors.As(err, &parseErr) || parseErr.Line != 2 || calls != 1 {
+		t.Errorf("Expected an error on line 2 after 1 call, got %d calls and %v", calls, err)
+	}
+}
+
+func TestCommaInts(t *testing.T) {
+	values, err := CommaInts(strings.NewReader("3,4, 3\n1,2\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if expected := []int{3, 4, 3, 1, 2}; !reflect.DeepEqual(values, expected) {
+		t.Errorf("Expected %v, got %v", expected, values)
+	}
+}
+
+func TestTrimmed(t *testing.T) {
+	text, offset := Line{1, " \t101 \t"}.Trimmed()
+	if text != "101" || offset != 2 {
+		t.Errorf("Expected \"101\" at 2, got %q at %d", text, offset)
+	}
+}
+
+func TestLineInts(t *testing.T) {
+	values, err := Line{1, " 22 13\t17  11 "}.Ints()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if expected := []int{22, 13, 17, 11}; !reflect.DeepEqual(values, expected) {
+		t.Errorf("Expected %v, got %v", expected, values)
+	}
+}
+
+func TestErrorPositions(t *testing.T) {
+	tests := []struct {
+		name   string
+		parse  func() error
+		line   int
+		column int
+	}{
+		{"Ints", func() error {
+			_, err := Ints(strings.NewReader("1\n2\n  x3\n"))
+			return err
+		}, 3, 3},
+		{"CommaInts", func() error {
+			_, err := CommaInts(strings.NewReader("1,2\n3,,4"))
+			return err
+		}, 2, 3},
+		{"Line.Ints", func() error {
+			_, err := Line{4, " 1  2 x3"}.Ints()
+			return err
+		}, 4, 7},
+		{"KeyValues", func() error {
+			_, err := KeyValues(strings.NewReader("a: 1\nb 2"), ":")
+			return err
+		}, 2, 0},
+		{"Records", func() error {
+			_, err := Records(strings.NewReader("\n\nfoo"), regexp.MustCompile(`^\d+$`))
+			return err
+		}, 3, 0},
+	}
+
+	for _, test := range tests {
+		err := test.parse()
+
+		var parseErr *Error
+		if !errors.As(err, &parseErr) {
+			t.Errorf("%s: expected an *Error, got %v", test.name, err)
+			continue
+		}
+
+		if parseErr.Line != test.line || parseErr.Column != test.column {
+			t.Errorf("%s: expected an error at %d:%d, got %v", test.name, test.line, test.column, err)
+		}
+	}
+}
+
+func TestErrorMessage(t *testing.T) {
+	_, err := Ints(strings.NewReader("12\nab"))
+	if err == nil || err.Error() != `line 2, column 1: invalid number "ab"` {
+		t.Errorf("Wrong error: %v", err)
+	}
+}
+
+func TestKeyValues(t *testing.T) {
+	kvs, err := KeyValues(strings.NewReader("ecl: gry\n\npid:860033327\n"), ":")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(kvs) != 2 {
+		t.Fatalf("Expected 2 key/values, got %d", len(kvs))
+	}
+
+	if kvs[0].Key != "ecl" || kvs[0].Value != "gry" || kvs[1].Key != "pid" || kvs[1].Value != "860033327" {
+		t.Errorf("Wrong key/values: %v", kvs)
+	}
+
+	if kvs[1].Line.Number != 3 {
+		t.Errorf("Expected the second key/value to be on line 3, got %d", kvs[1].Line.Number)
+	}
+}
+
+func TestRecords(t *testing.T) {
+	re := regexp.MustCompile(`^(\d+)-(\d+) (\w): (\w+)$`)
+
+	if _, err := Records(strings.NewReader("1-3 a: abcde\n1-x3 b: cdefg"), re); err == nil {
+		t.Fatal("Expected the second line not to match")
+	}
+
+	// a looser expression that lets a bad number through to Int
+	re = regexp.MustCompile(`^(\d+)-(\w+) (\w): (\w+)$`)
+
+	records, err := Records(strings.NewReader("1-3 a: abcde\n10-x b: cdefg"), re)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if expected := []string{"1", "3", "a", "abcde"}; !reflect.DeepEqual(records[0].Fields, expected) {
+		t.Errorf("Expected fields %v, got %v", expected, records[0].Fields)
+	}
+
+	if n, err := records[0].Int(1); n != 3 || err != nil {
+		t.Errorf("Expected 3, got %d (%v)", n, err)
+	}
+
+	_, err = records[1].Int(1)
+
+	var parseErr *Error
+	if !errors.As(err, &parseErr) || parseErr.Line != 2 || parseErr.Column != 4 {
+		t.Errorf("Expected an error at 2:4, got %v", err)
+	}
+}
@@ -0,0 +1,162 @@
+package aoc
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// Progress is how a long-running puzzler reports how far along it is, so that
+// whoever is running it can show a progress line, throughput and an estimate
+// of the time remaining. Puzzlers get theirs from their context using
+// ProgressFrom.
+//
+// Progress is counted in whatever units of work suit the puzzle, e.g. cuboids
+// processed or positions explored.
+type Progress interface {
+	// SetTotal records how many units of work there are in all. Puzzlers that
+	// can't know this in advance don't need to call it.
+	SetTotal(total int64)
+
+	// Add records that n more units of work are done. It is cheap enough to
+	// call from an inner loop.
+	Add(n int64)
+}
+
+// ProgressStatus is a snapshot of a Tracker.
+type ProgressStatus struct {
+	Done  int64
+	Total int64
+
+	// Time since the tracker was created
+	Elapsed time.Duration
+}
+
+// Tracker is a Progress that keeps count, so that it can be reported on while
+// the puzzle is still running. It is safe for concurrent use.
+type Tracker struct {
+	// accessed atomically, so kept first to ensure 64-bit alignment
+	done  int64
+	total int64
+
+	start time.Time
+}
+
+type progressKey struct{}
+
+// noProgress is the Progress used by puzzlers when nobody is watching
+type noProgress struct{}
+
+// WithProgress returns a copy of ctx that carries p, for a puzzler to report
+// its progress to.
+func WithProgress(ctx context.Context, p Progress) context.Context {
+	return context.WithValue(ctx, progressKey{}, p)
+}
+
+// ProgressFrom returns the Progress carried by ctx. If there isn't one, the
+// Progress returned does nothing, so puzzlers can always report progress
+// without checking first.
+func ProgressFrom(ctx context.Context) Progress {
+	if p, ok := ctx.Value(progressKey{}).(Progress); ok {
+		return p
+	}
+	return noProgress{}
+}
+
+func (noProgress) SetTotal(total int64) {}
+
+func (noProgress) Add(n int64) {}
+
+// NewTracker returns a Tracker that starts timing now
+func NewTracker() *Tracker {
+	return &Tracker{start: time.Now()}
+}
+
+func (t *Tracker) SetTotal(total int64) {
+	atomic.StoreInt64(&t.total, total)
+}
+
+func (t *Tracker) Add(n int64) {
+	atomic.AddInt64(&t.done, n)
+}
+
+// Status returns how far along the puzzle is right now
+func (t *Tracker) Status() ProgressStatus {
+	return ProgressStatus{
+		Done:    atomic.LoadInt64(&t.done),
+		Total:   atomic.LoadInt64(&t.total),
+		Elapsed: time.Since(t.start),
+	}
+}
+
+// Started returns whether any progress has been reported at all
+func (s ProgressStatus) Started() bool {
+	return s.Done > 0 || s.Total > 0
+}
+
+// Rate returns the number of units of work done per second so far
+func (s ProgressStatus) Rate() float64 {
+	if s.Elapsed <= 0 {
+		return 0
+	}
+	return float64(s.Done) / s.Elapsed.Seconds()
+}
+
+// ETA estimates how much longer the rest of the work will take at the rate
+// seen so far. The second value is false if there's no way to tell, i.e. the
+// total isn't known or nothing is done yet.
+func (s ProgressStatus) ETA() (time.Duration, bool) {
+	rate := s.Rate()
+	if s.Total <= 0 || rate <= 0 {
+		return 0, false
+	}
+
+	remaining := s.Total - s.Done
+	if remaining < 0 {
+		remaining = 0
+	}
+
+	return time.Duration(float64(remaining) / rate * float64(time.Second)), true
+}
+
+// String describes s in a single line, e.g.
+// "1500/6000 (25.0%), 3.0k/s, 1.5s remaining"
+func (s ProgressStatus) String() string {
+	var b strings.Builder
+
+	if s.Total > 0 {
+		fmt.Fprintf(&b, "%d/%d (%.1f%%)", s.Done, s.Total, float64(s.Done)/float64(s.Total)*100)
+	} else {
+		fmt.Fprintf(&b, "%d done", s.Done)
+	}
+
+	fmt.Fprintf(&b, ", %s/s", formatCount(s.Rate()))
+
+	if eta, ok := s.ETA(); ok {
+		fmt.Fprintf(&b, ", %v remaining", eta.Round(100*time.Millisecond))
+	}
+
+	return b.String()
+}
+
+// formatCount returns a short version of n, e.g. "1.2M"
+func formatCount(n float64) string {
+	const unit = 1000
+
+	if n < unit {
+		return fmt.Sprintf("%.0f", n)
+	}
+
+	value := n / unit
+	prefixes := "kMGTPE"
+	i := 0
+
+	for value >= unit && i < len(prefixes)-1 {
+		value /= unit
+		i++
+	}
+
+	return fmt.Sprintf("%.1f%c", value, prefixes[i])
+}
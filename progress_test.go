@@ -0,0 +1,59 @@
+package aoc
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestProgressFrom(t *testing.T) {
+	// Reporting progress with nobody watching is fine
+	p := ProgressFrom(context.Background())
+	p.SetTotal(10)
+	p.Add(1)
+
+	tracker := NewTracker()
+	ctx := WithProgress(context.Background(), tracker)
+
+	ProgressFrom(ctx).SetTotal(10)
+	ProgressFrom(ctx).Add(3)
+	ProgressFrom(ctx).Add(4)
+
+	status := tracker.Status()
+	if status.Done != 7 || status.Total != 10 {
+		t.Errorf("Expected 7/10, got %d/%d", status.Done, status.Total)
+	}
+}
+
+func TestProgressStatus(t *testing.T) {
+	tests := []struct {
+		status   ProgressStatus
+		expected string
+	}{
+		{ProgressStatus{0, 0, time.Second}, "0 done, 0/s"},
+		{ProgressStatus{1500, 6000, time.Second / 2}, "1500/6000 (25.0%), 3.0k/s, 1.5s remaining"},
+		{ProgressStatus{2500000, 0, time.Second}, "2500000 done, 2.5M/s"},
+		{ProgressStatus{10, 10, time.Second}, "10/10 (100.0%), 10/s, 0s remaining"},
+	}
+
+	for _, test := range tests {
+		if actual := test.status.String(); actual != test.expected {
+			t.Errorf("Expected %q, got %q", test.expected, actual)
+		}
+	}
+}
+
+func TestProgressStatusETA(t *testing.T) {
+	if _, ok := (ProgressStatus{0, 100, time.Second}).ETA(); ok {
+		t.Errorf("Expected no ETA before any work is done")
+	}
+
+	if _, ok := (ProgressStatus{50, 0, time.Second}).ETA(); ok {
+		t.Errorf("Expected no ETA without a total")
+	}
+
+	eta, ok := ProgressStatus{25, 100, time.Second}.ETA()
+	if !ok || eta != 3*time.Second {
+		t.Errorf("Expected an ETA of 3s, got %v (%v)", eta, ok)
+	}
+}
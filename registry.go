@@ -0,0 +1,88 @@
+package aoc
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+var (
+	registryMutex sync.Mutex
+
+	// registered days, by year and then by day number
+	registry = make(map[int]map[int]Day)
+)
+
+// Register makes day available as part of the given year. Each day's package
+// calls it from its init function, e.g.:
+//
+//	func init() {
+//		aoc.Register(2021, New())
+//	}
+//
+// so that importing the package is all it takes to make the day available.
+// Register panics if a day is registered twice for the same year.
+func Register(year int, day Day) {
+	registryMutex.Lock()
+	defer registryMutex.Unlock()
+
+	days, found := registry[year]
+	if !found {
+		days = make(map[int]Day)
+		registry[year] = days
+	}
+
+	if _, found := days[day.number]; found {
+		panic(fmt.Sprintf("aoc: day %d of %d registered twice", day.number, year))
+	}
+
+	days[day.number] = day
+}
+
+// Years returns every year that has at least one registered day, ordered by
+// number.
+func Years() []Year {
+	registryMutex.Lock()
+	defer registryMutex.Unlock()
+
+	var numbers []int
+	for number := range registry {
+		numbers = append(numbers, number)
+	}
+	sort.Ints(numbers)
+
+	result := make([]Year, len(numbers))
+	for i, number := range numbers {
+		result[i] = registeredYear(number)
+	}
+
+	return result
+}
+
+// LookupYear returns the given year + a flag indicating whether any days have
+// been registered for it.
+func LookupYear(number int) (Year, bool) {
+	registryMutex.Lock()
+	defer registryMutex.Unlock()
+
+	if _, found := registry[number]; !found {
+		return Year{}, false
+	}
+
+	return registeredYear(number), true
+}
+
+// registeredYear builds a Year out of the days registered for it, ordered by
+// day number. registryMutex must be held.
+func registeredYear(number int) Year {
+	var days []Day
+	for _, day := range registry[number] {
+		days = append(days, day)
+	}
+
+	sort.Slice(days, func(i, j int) bool {
+		return days[i].number < days[j].number
+	})
+
+	return NewYear(number, days...)
+}
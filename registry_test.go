@@ -0,0 +1,46 @@
+package aoc
+
+import (
+	"testing"
+)
+
+func TestRegister(t *testing.T) {
+	// Use a year that real puzzles will never be registered for
+	const year = 1
+
+	Register(year, NewDay(3, ""))
+	Register(year, NewDay(1, ""))
+
+	y, found := LookupYear(year)
+	if !found {
+		t.Fatalf("Year %d was not found", year)
+	}
+
+	days := y.Days()
+	if len(days) != 2 || days[0].Number() != 1 || days[1].Number() != 3 {
+		t.Errorf("Expected days 1 and 3, in order, got %v", days)
+	}
+
+	years := Years()
+	if len(years) == 0 || years[0].Number() != year {
+		t.Errorf("Expected year %d to be listed first, got %v", year, years)
+	}
+
+	if _, found := LookupYear(year + 1); found {
+		t.Errorf("Year %d should not have been found", year+1)
+	}
+}
+
+func TestRegisterTwicePanics(t *testing.T) {
+	const year = 2
+
+	Register(year, NewDay(1, ""))
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Expected registering a day twice to panic")
+		}
+	}()
+
+	Register(year, NewDay(1, ""))
+}
@@ -0,0 +1,96 @@
+package search
+
+// Queue is a priority queue backed by a binary min-heap. Values with the
+// lowest priority are popped first; values with equal priorities are popped
+// in the order they were pushed. The zero value is an empty queue.
+type Queue struct {
+	items  []queueItem
+	pushed int
+}
+
+type queueItem struct {
+	value    interface{}
+	priority int
+
+	// order in which the item was pushed, used to break ties
+	seq int
+}
+
+// Len returns the number of values in q
+func (q *Queue) Len() int {
+	return len(q.items)
+}
+
+// Push adds value to q with the given priority
+func (q *Queue) Push(value interface{}, priority int) {
+	q.items = append(q.items, queueItem{value, priority, q.pushed})
+	q.pushed++
+	q.up(len(q.items) - 1)
+}
+
+// Pop removes and returns the value with the lowest priority, along with that
+// priority. It panics if q is empty.
+func (q *Queue) Pop() (interface{}, int) {
+	if len(q.items) == 0 {
+		panic("search: Pop called on an empty Queue")
+	}
+
+	top := q.items[0]
+	last := len(q.items) - 1
+
+	q.items[0] = q.items[last]
+	q.items[last] = queueItem{}
+	q.items = q.items[:last]
+
+	if last > 0 {
+		q.down(0)
+	}
+
+	return top.value, top.priority
+}
+
+// Peek returns the value that Pop would return without removing it. It panics
+// if q is empty.
+func (q *Queue) Peek() (interface{}, int) {
+	if len(q.items) == 0 {
+		panic("search: Peek called on an empty Queue")
+	}
+	return q.items[0].value, q.items[0].priority
+}
+
+func (q *Queue) less(i, j int) bool {
+	a, b := q.items[i], q.items[j]
+	if a.priority != b.priority {
+		return a.priority < b.priority
+	}
+	return a.seq < b.seq
+}
+
+func (q *Queue) up(i int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if !q.less(i, parent) {
+			return
+		}
+		q.items[i], q.items[parent] = q.items[parent], q.items[i]
+		i = parent
+	}
+}
+
+func (q *Queue) down(i int) {
+	n := len(q.items)
+	for {
+		smallest := i
+		if left := 2*i + 1; left < n && q.less(left, smallest) {
+			smallest = left
+		}
+		if right := 2*i + 2; right < n && q.less(right, smallest) {
+			smallest = right
+		}
+		if smallest == i {
+			return
+		}
+		q.items[i], q.items[smallest] = q.items[smallest], q.items[i]
+		i = smallest
+	}
+}
@@ -0,0 +1,49 @@
+package search
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestQueueOrder(t *testing.T) {
+	var q Queue
+
+	for i, p := range []int{5, 3, 8, 1, 9, 3, 0, 7} {
+		q.Push(i, p)
+	}
+
+	if q.Len() != 8 {
+		t.Fatalf("Expected 8 values, got %d", q.Len())
+	}
+
+	if v, p := q.Peek(); v != 6 || p != 0 {
+		t.Errorf("Expected Peek to return 6 (0), got %v (%d)", v, p)
+	}
+
+	var values, priorities []int
+	for q.Len() > 0 {
+		v, p := q.Pop()
+		values = append(values, v.(int))
+		priorities = append(priorities, p)
+	}
+
+	// ties (the two 3s) come out in the order they went in
+	if expected := []int{6, 3, 1, 5, 0, 7, 2, 4}; !reflect.DeepEqual(values, expected) {
+		t.Errorf("Expected values %v, got %v", expected, values)
+	}
+
+	if expected := []int{0, 1, 3, 3, 5, 7, 8, 9}; !reflect.DeepEqual(priorities, expected) {
+		t.Errorf("Expected priorities %v, got %v", expected, priorities)
+	}
+}
+
+func TestQueuePopEmpty(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Expected Pop to panic on an empty queue")
+		}
+	}()
+
+	var q Queue
+	q.Pop()
+}
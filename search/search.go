@@ -0,0 +1,150 @@
+// Package search finds the cheapest path through a weighted graph, using
+// Dijkstra's algorithm or, when given a heuristic, A*.
+//
+// Graphs are described by a Graph, which lists the edges leading out of each
+// state. States can be anything comparable (they are used as map keys), so a
+// grid.Point works as-is, while states built from slices need to be encoded,
+// e.g. as a string.
+package search
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrNoPath is returned when no goal state can be reached from the start.
+var ErrNoPath = errors.New("no path found")
+
+// State is a node in a graph. States must be comparable.
+type State interface{}
+
+// Edge leads from one state to another at a non-negative cost.
+type Edge struct {
+	To   State
+	Cost int
+}
+
+// Graph describes the states that can be reached from each state.
+type Graph interface {
+	// Neighbors returns the edges leading out of s
+	Neighbors(s State) []Edge
+}
+
+// GraphFunc adapts an ordinary function into a Graph.
+type GraphFunc func(s State) []Edge
+
+// Heuristic estimates the cost of getting from s to the nearest goal. To get
+// the cheapest path it must never overestimate, and the estimate must not
+// drop by more than the cost of any single edge (i.e. it is consistent).
+type Heuristic func(s State) int
+
+// Step is a state along a path, with the total cost of reaching it from the
+// start.
+type Step struct {
+	State State
+	Cost  int
+}
+
+// Result describes the cheapest path found by a search.
+type Result struct {
+	// The states visited, from the start to the goal (inclusive)
+	Path []Step
+
+	// Total cost of the path
+	Cost int
+
+	// Number of states expanded during the search
+	Expanded int
+}
+
+// searchNode records the cheapest known way of reaching a state
+type searchNode struct {
+	cost   int
+	parent State
+	closed bool
+}
+
+func (f GraphFunc) Neighbors(s State) []Edge {
+	return f(s)
+}
+
+// Dijkstra returns the cheapest path from start to a state for which isGoal
+// returns true. It returns ErrNoPath if there isn't one, and ctx.Err() if ctx
+// is done before the search completes.
+func Dijkstra(ctx context.Context, g Graph, start State, isGoal func(s State) bool) (*Result, error) {
+	return AStar(ctx, g, start, isGoal, nil)
+}
+
+// AStar is like Dijkstra, but uses h to direct the search toward the goal,
+// which usually means expanding far fewer states. If h is nil, AStar is the
+// same as Dijkstra.
+func AStar(ctx context.Context, g Graph, start State, isGoal func(s State) bool, h Heuristic) (*Result, error) {
+	if h == nil {
+		h = func(State) int { return 0 }
+	}
+
+	nodes := map[State]*searchNode{start: {}}
+	expanded := 0
+
+	var q Queue
+	q.Push(start, h(start))
+
+	for q.Len() > 0 {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		value, _ := q.Pop()
+		s := value.(State)
+		node := nodes[s]
+
+		// A state can be queued more than once if a cheaper way to reach it
+		// turns up; only the first (cheapest) one counts
+		if node.closed {
+			continue
+		}
+		node.closed = true
+
+		if isGoal(s) {
+			return &Result{
+				Path:     buildPath(nodes, start, s),
+				Cost:     node.cost,
+				Expanded: expanded,
+			}, nil
+		}
+
+		expanded++
+
+		for _, e := range g.Neighbors(s) {
+			cost := node.cost + e.Cost
+
+			next, found := nodes[e.To]
+			if found && (next.closed || next.cost <= cost) {
+				continue
+			}
+
+			nodes[e.To] = &searchNode{cost: cost, parent: s}
+			q.Push(e.To, cost+h(e.To))
+		}
+	}
+
+	return nil, ErrNoPath
+}
+
+// buildPath follows parents back from goal to start
+func buildPath(nodes map[State]*searchNode, start, goal State) []Step {
+	var path []Step
+
+	for s := goal; ; s = nodes[s].parent {
+		path = append(path, Step{s, nodes[s].cost})
+		if s == start {
+			break
+		}
+	}
+
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+
+	return path
+}
@@ -0,0 +1,127 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/matthinz/aoc-golang/grid"
+)
+
+// a small graph where the direct route is not the cheapest one
+var letters = GraphFunc(func(s State) []Edge {
+	return map[State][]Edge{
+		"a": {{"b", 7}, {"c", 9}, {"f", 14}},
+		"b": {{"a", 7}, {"c", 10}, {"d", 15}},
+		"c": {{"a", 9}, {"b", 10}, {"d", 11}, {"f", 2}},
+		"d": {{"b", 15}, {"c", 11}, {"e", 6}},
+		"e": {{"d", 6}, {"f", 9}},
+		"f": {{"a", 14}, {"c", 2}, {"e", 9}},
+	}[s]
+})
+
+func is(goal State) func(State) bool {
+	return func(s State) bool {
+		return s == goal
+	}
+}
+
+func TestDijkstra(t *testing.T) {
+	result, err := Dijkstra(context.Background(), letters, "a", is("e"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result.Cost != 20 {
+		t.Errorf("Expected cost 20, got %d", result.Cost)
+	}
+
+	expected := []Step{{"a", 0}, {"c", 9}, {"f", 11}, {"e", 20}}
+	if !reflect.DeepEqual(result.Path, expected) {
+		t.Errorf("Expected path %v, got %v", expected, result.Path)
+	}
+}
+
+func TestStartIsGoal(t *testing.T) {
+	result, err := Dijkstra(context.Background(), letters, "d", is("d"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if expected := []Step{{"d", 0}}; result.Cost != 0 || !reflect.DeepEqual(result.Path, expected) {
+		t.Errorf("Expected path %v at cost 0, got %v at cost %d", expected, result.Path, result.Cost)
+	}
+}
+
+func TestNoPath(t *testing.T) {
+	_, err := Dijkstra(context.Background(), letters, "a", is("z"))
+	if !errors.Is(err, ErrNoPath) {
+		t.Errorf("Expected ErrNoPath, got %v", err)
+	}
+}
+
+func TestCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := Dijkstra(ctx, letters, "a", is("e"))
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+}
+
+func TestAStarOnGrid(t *testing.T) {
+	// walls (9s) force a detour down and back up
+	g := grid.FromRows([][]int{
+		{1, 9, 1, 1},
+		{1, 9, 1, 9},
+		{1, 1, 1, 1},
+	})
+	start := grid.Point{X: 0, Y: 0}
+	goal := grid.Point{X: 3, Y: 0}
+
+	graph := GraphFunc(func(s State) []Edge {
+		var edges []Edge
+		for _, n := range g.Neighbors4(s.(grid.Point)) {
+			if v := g.Get(n); v != 9 {
+				edges = append(edges, Edge{n, v})
+			}
+		}
+		return edges
+	})
+
+	manhattan := func(s State) int {
+		p := s.(grid.Point)
+		dx, dy := goal.X-p.X, goal.Y-p.Y
+		if dx < 0 {
+			dx = -dx
+		}
+		if dy < 0 {
+			dy = -dy
+		}
+		return dx + dy
+	}
+
+	dijkstra, err := Dijkstra(context.Background(), graph, start, is(goal))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	astar, err := AStar(context.Background(), graph, start, is(goal), manhattan)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if dijkstra.Cost != 7 || astar.Cost != 7 {
+		t.Errorf("Expected cost 7, got %d (Dijkstra) and %d (A*)", dijkstra.Cost, astar.Cost)
+	}
+
+	if len(astar.Path) != 8 || astar.Path[7].State != goal {
+		t.Errorf("Expected an 8 step path ending at %v, got %v", goal, astar.Path)
+	}
+
+	if astar.Expanded > dijkstra.Expanded {
+		t.Errorf("A* expanded %d states, more than Dijkstra's %d", astar.Expanded, dijkstra.Expanded)
+	}
+}
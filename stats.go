@@ -0,0 +1,120 @@
+package aoc
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"runtime"
+	"time"
+)
+
+// How often the heap is sampled while a puzzle runs
+const heapSampleInterval = 10 * time.Millisecond
+
+// Stats describes the resources used while solving a puzzle.
+type Stats struct {
+	// Wall time taken
+	Duration time.Duration `json:"duration_ns"`
+
+	// Number of heap objects allocated
+	Allocs uint64 `json:"allocs"`
+
+	// Total bytes allocated on the heap
+	AllocBytes uint64 `json:"alloc_bytes"`
+
+	// Most the heap grew by while the puzzle ran, above what was live when it
+	// started
+	PeakHeap uint64 `json:"peak_heap_bytes"`
+}
+
+// SolveWithStats is like Solve, but also measures the time and memory used.
+//
+// A garbage collection is run first, so that the peak heap is measured from
+// what is actually live rather than from whatever garbage earlier puzzles left
+// behind. Memory statistics are still process-wide, though, so they include
+// anything else that was running at the same time (e.g. other puzzles being
+// solved concurrently). The peak heap is sampled periodically and so may miss
+// short-lived spikes.
+func (p Puzzler) SolveWithStats(ctx context.Context, r io.Reader, l *Logger) (string, Stats, error) {
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+
+	peak := before.HeapAlloc
+	done := make(chan struct{})
+	sampled := make(chan uint64)
+
+	go func() {
+		ticker := time.NewTicker(heapSampleInterval)
+		defer ticker.Stop()
+
+		max := uint64(0)
+		var m runtime.MemStats
+
+		for {
+			select {
+			case <-ticker.C:
+				runtime.ReadMemStats(&m)
+				if m.HeapAlloc > max {
+					max = m.HeapAlloc
+				}
+			case <-done:
+				sampled <- max
+				return
+			}
+		}
+	}()
+
+	start := time.Now()
+	answer, err := p.Solve(ctx, r, l)
+	elapsed := time.Since(start)
+
+	close(done)
+	if max := <-sampled; max > peak {
+		peak = max
+	}
+
+	runtime.ReadMemStats(&after)
+	if after.HeapAlloc > peak {
+		peak = after.HeapAlloc
+	}
+
+	stats := Stats{
+		Duration:   elapsed,
+		Allocs:     after.Mallocs - before.Mallocs,
+		AllocBytes: after.TotalAlloc - before.TotalAlloc,
+		PeakHeap:   peak - before.HeapAlloc,
+	}
+
+	return answer, stats, err
+}
+
+func (s Stats) String() string {
+	return fmt.Sprintf(
+		"%v, %d allocs (%s), peak heap %s",
+		s.Duration,
+		s.Allocs,
+		FormatBytes(s.AllocBytes),
+		FormatBytes(s.PeakHeap),
+	)
+}
+
+// FormatBytes returns a human-readable version of n, e.g. "1.5 MiB"
+func FormatBytes(n uint64) string {
+	const unit = 1024
+
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+
+	value := float64(n) / unit
+	prefixes := "KMGTPE"
+	i := 0
+
+	for value >= unit && i < len(prefixes)-1 {
+		value /= unit
+		i++
+	}
+
+	return fmt.Sprintf("%.1f %ciB", value, prefixes[i])
+}
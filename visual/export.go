@@ -0,0 +1,146 @@
+package visual
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/gif"
+	"image/png"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Images are scaled up so that small frames are still easy to see: each cell
+// becomes a square big enough that the largest frame is at least
+// minImageSize pixels across, up to maxScale pixels per cell.
+const (
+	minImageSize = 400
+	maxScale     = 16
+)
+
+// errNoFrames is returned when asked to export a simulation that didn't
+// record anything
+var errNoFrames = errors.New("no frames were recorded")
+
+// WriteGIF writes frames to w as an animated GIF that loops forever, showing
+// each frame for delay. Frames that are smaller than the largest are centered
+// on it, so that a simulation whose frames grow appears to grow outward.
+func WriteGIF(w io.Writer, frames []*Frame, delay time.Duration) error {
+	if len(frames) == 0 {
+		return errNoFrames
+	}
+
+	scale := scaleFor(frames)
+	width, height := largest(frames)
+	bounds := image.Rect(0, 0, width*scale, height*scale)
+
+	anim := gif.GIF{
+		Config: image.Config{
+			ColorModel: frames[0].Image(1).Palette,
+			Width:      bounds.Dx(),
+			Height:     bounds.Dy(),
+		},
+	}
+
+	// GIF delays are in hundredths of a second
+	centiseconds := int(delay / (10 * time.Millisecond))
+
+	for _, f := range frames {
+		img := f.Image(scale)
+
+		if img.Bounds() != bounds {
+			// fill the rest of the canvas with the first style
+			canvas := image.NewPaletted(bounds, img.Palette)
+			offset := image.Point{
+				X: (bounds.Dx() - img.Bounds().Dx()) / 2,
+				Y: (bounds.Dy() - img.Bounds().Dy()) / 2,
+			}
+			draw.Draw(canvas, img.Bounds().Add(offset), img, image.Point{}, draw.Src)
+			img = canvas
+		}
+
+		anim.Image = append(anim.Image, img)
+		anim.Delay = append(anim.Delay, centiseconds)
+	}
+
+	return gif.EncodeAll(w, &anim)
+}
+
+// WritePNGs writes each frame to its own PNG file, numbered in order and
+// named after name, e.g. "out/sim.png" gives "out/sim-0001.png",
+// "out/sim-0002.png" and so on. It returns the names of the files written.
+func WritePNGs(name string, frames []*Frame) ([]string, error) {
+	if len(frames) == 0 {
+		return nil, errNoFrames
+	}
+
+	scale := scaleFor(frames)
+	ext := filepath.Ext(name)
+	base := strings.TrimSuffix(name, ext)
+
+	var names []string
+
+	for i, f := range frames {
+		file := fmt.Sprintf("%s-%04d%s", base, i+1, ext)
+		if err := writePNG(file, f.Image(scale)); err != nil {
+			return names, err
+		}
+		names = append(names, file)
+	}
+
+	return names, nil
+}
+
+func writePNG(name string, img image.Image) error {
+	f, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+
+	if err := png.Encode(f, img); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+// scaleFor returns how many pixels across each cell should be when exporting
+// frames
+func scaleFor(frames []*Frame) int {
+	width, height := largest(frames)
+
+	size := width
+	if height > size {
+		size = height
+	}
+
+	if size == 0 {
+		return 1
+	}
+
+	scale := (minImageSize + size - 1) / size
+	if scale > maxScale {
+		scale = maxScale
+	}
+
+	return scale
+}
+
+// largest returns the width of the widest frame and height of the tallest
+func largest(frames []*Frame) (int, int) {
+	var width, height int
+	for _, f := range frames {
+		if f.Width > width {
+			width = f.Width
+		}
+		if f.Height > height {
+			height = f.Height
+		}
+	}
+	return width, height
+}
@@ -0,0 +1,104 @@
+package visual
+
+import (
+	"bytes"
+	"image/gif"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestWriteGIF(t *testing.T) {
+	small := NewFrame(2, 2, testPalette)
+	small.Set(0, 0, 1)
+	big := NewFrame(4, 4, testPalette)
+
+	var b bytes.Buffer
+	if err := WriteGIF(&b, []*Frame{small, big}, 250*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+
+	anim, err := gif.DecodeAll(&b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(anim.Image) != 2 {
+		t.Fatalf("Expected 2 frames, got %d", len(anim.Image))
+	}
+
+	// cells are scaled up as far as maxScale allows
+	if anim.Config.Width != 64 || anim.Config.Height != 64 {
+		t.Errorf("Expected 64x64, got %dx%d", anim.Config.Width, anim.Config.Height)
+	}
+
+	for i, img := range anim.Image {
+		if img.Bounds().Dx() != 64 || img.Bounds().Dy() != 64 {
+			t.Errorf("frame %d: expected 64x64, got %v", i, img.Bounds())
+		}
+		if anim.Delay[i] != 25 {
+			t.Errorf("frame %d: expected a delay of 25, got %d", i, anim.Delay[i])
+		}
+	}
+
+	// the small frame is centered, with a border of the first style around it
+	if c := anim.Image[0].ColorIndexAt(20, 20); c != 1 {
+		t.Errorf("Expected small frame to be centered, got %d at 20,20", c)
+	}
+	if c := anim.Image[0].ColorIndexAt(5, 5); c != 0 {
+		t.Errorf("Expected padding around small frame, got %d at 5,5", c)
+	}
+}
+
+func TestWriteGIFNoFrames(t *testing.T) {
+	var b bytes.Buffer
+	if err := WriteGIF(&b, nil, time.Second); err != errNoFrames {
+		t.Errorf("Expected errNoFrames, got %v", err)
+	}
+}
+
+func TestWritePNGs(t *testing.T) {
+	dir := t.TempDir()
+
+	frames := []*Frame{
+		NewFrame(1000, 10, testPalette),
+		NewFrame(1000, 10, testPalette),
+	}
+
+	names, err := WritePNGs(filepath.Join(dir, "sim.png"), frames)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{
+		filepath.Join(dir, "sim-0001.png"),
+		filepath.Join(dir, "sim-0002.png"),
+	}
+
+	if len(names) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, names)
+	}
+
+	for i, name := range names {
+		if name != expected[i] {
+			t.Errorf("Expected %s, got %s", expected[i], name)
+		}
+
+		f, err := os.Open(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		img, err := png.Decode(f)
+		f.Close()
+		if err != nil {
+			t.Fatalf("%s: %s", name, err)
+		}
+
+		// big enough frames aren't scaled
+		if img.Bounds().Dx() != 1000 || img.Bounds().Dy() != 10 {
+			t.Errorf("%s: expected 1000x10, got %v", name, img.Bounds())
+		}
+	}
+}
@@ -0,0 +1,128 @@
+// Package visual records the frames of a simulation so that they can be
+// watched afterward, either played back as an animation in a terminal or
+// exported as an animated GIF or a sequence of PNGs.
+//
+// Puzzlers get a Recorder from their context using RecorderFrom and, if there
+// is one, hand it a Frame after each step of the simulation.
+package visual
+
+import (
+	"image"
+	"image/color"
+	"strings"
+
+	"github.com/matthinz/aoc-golang/grid"
+)
+
+// Style is how one kind of cell is drawn: as a rune in a terminal, and as a
+// colored square in an image.
+type Style struct {
+	Rune  rune
+	Color color.RGBA
+}
+
+// RGB returns the opaque color with the given red, green and blue components,
+// for use in a Style
+func RGB(r, g, b uint8) color.RGBA {
+	return color.RGBA{r, g, b, 0xff}
+}
+
+// Palette lists the styles a frame's cells can have. A frame can have at most
+// 256 of them.
+type Palette []Style
+
+// Frame is a single picture of a simulation: a rectangle of cells, each of
+// which has one of the styles in the palette.
+type Frame struct {
+	Width, Height int
+	Palette       Palette
+
+	// shown along with the frame, e.g. "step 12"
+	Caption string
+
+	// index into Palette of each cell, row by row
+	Cells []uint8
+}
+
+// NewFrame returns a width x height frame with every cell drawn in the first
+// style in palette
+func NewFrame(width, height int, palette Palette) *Frame {
+	return &Frame{
+		Width:   width,
+		Height:  height,
+		Palette: palette,
+		Cells:   make([]uint8, width*height),
+	}
+}
+
+// GridFrame returns a frame the same size as g, with each cell drawn in the
+// style that style picks for its value
+func GridFrame(g *grid.Grid, palette Palette, style func(value int) uint8) *Frame {
+	f := NewFrame(g.Width(), g.Height(), palette)
+	g.Each(func(p grid.Point, value int) {
+		f.Set(p.X, p.Y, style(value))
+	})
+	return f
+}
+
+// Copy returns a new frame that looks the same as f, for simulations that
+// draw each frame on top of the last one
+func (f *Frame) Copy() *Frame {
+	c := *f
+	c.Cells = make([]uint8, len(f.Cells))
+	copy(c.Cells, f.Cells)
+	return &c
+}
+
+// Set gives the cell at x,y the style at index in f's palette. Cells outside
+// of the frame are ignored.
+func (f *Frame) Set(x, y int, index uint8) {
+	if x < 0 || y < 0 || x >= f.Width || y >= f.Height {
+		return
+	}
+	f.Cells[y*f.Width+x] = index
+}
+
+// At returns the index into f's palette of the style of the cell at x,y
+func (f *Frame) At(x, y int) uint8 {
+	return f.Cells[y*f.Width+x]
+}
+
+// Image draws f with each cell as a scale x scale square
+func (f *Frame) Image(scale int) *image.Paletted {
+	colors := make(color.Palette, len(f.Palette))
+	for i, s := range f.Palette {
+		colors[i] = s.Color
+	}
+
+	img := image.NewPaletted(image.Rect(0, 0, f.Width*scale, f.Height*scale), colors)
+
+	for y := 0; y < f.Height; y++ {
+		for x := 0; x < f.Width; x++ {
+			index := f.At(x, y)
+			for dy := 0; dy < scale; dy++ {
+				row := img.Pix[(y*scale+dy)*img.Stride:]
+				for dx := 0; dx < scale; dx++ {
+					row[x*scale+dx] = index
+				}
+			}
+		}
+	}
+
+	return img
+}
+
+// String draws f using each style's rune, with one line per row and no
+// trailing newline
+func (f *Frame) String() string {
+	var b strings.Builder
+	for y := 0; y < f.Height; y++ {
+		if y > 0 {
+			b.WriteString("\n")
+		}
+		for x := 0; x < f.Width; x++ {
+			b.WriteRune(f.Palette[f.At(x, y)].Rune)
+		}
+	}
+	return b.String()
+}
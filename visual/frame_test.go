@@ -0,0 +1,97 @@
+package visual
+
+import (
+	"testing"
+
+	"github.com/matthinz/aoc-golang/grid"
+)
+
+var testPalette = Palette{
+	{Rune: '.', Color: RGB(0, 0, 0)},
+	{Rune: '#', Color: RGB(255, 255, 255)},
+	{Rune: 'o', Color: RGB(255, 0, 0)},
+}
+
+func TestFrame(t *testing.T) {
+	f := NewFrame(3, 2, testPalette)
+	f.Set(0, 0, 1)
+	f.Set(2, 1, 2)
+
+	// outside of the frame, so ignored
+	f.Set(3, 0, 1)
+	f.Set(-1, 1, 1)
+
+	expected := "#..\n..o"
+	if actual := f.String(); actual != expected {
+		t.Errorf("Expected %q, got %q", expected, actual)
+	}
+
+	if f.At(2, 1) != 2 {
+		t.Errorf("Expected 2 at 2,1, got %d", f.At(2, 1))
+	}
+}
+
+func TestFrameCopy(t *testing.T) {
+	f := NewFrame(2, 1, testPalette)
+	f.Caption = "before"
+
+	c := f.Copy()
+	c.Set(0, 0, 1)
+
+	if f.At(0, 0) != 0 {
+		t.Errorf("Changing a copy changed the original")
+	}
+
+	if c.Caption != "before" || c.Width != 2 || c.Height != 1 {
+		t.Errorf("Copy doesn't match: %+v", c)
+	}
+}
+
+func TestGridFrame(t *testing.T) {
+	g := grid.FromRows([][]int{
+		{0, 5},
+		{7, 0},
+	})
+
+	f := GridFrame(g, testPalette, func(value int) uint8 {
+		if value > 5 {
+			return 2
+		}
+		if value > 0 {
+			return 1
+		}
+		return 0
+	})
+
+	expected := ".#\no."
+	if actual := f.String(); actual != expected {
+		t.Errorf("Expected %q, got %q", expected, actual)
+	}
+}
+
+func TestFrameImage(t *testing.T) {
+	f := NewFrame(2, 1, testPalette)
+	f.Set(1, 0, 2)
+
+	img := f.Image(3)
+
+	if img.Bounds().Dx() != 6 || img.Bounds().Dy() != 3 {
+		t.Fatalf("Expected a 6x3 image, got %v", img.Bounds())
+	}
+
+	tests := []struct {
+		x, y     int
+		expected uint8
+	}{
+		{0, 0, 0},
+		{2, 2, 0},
+		{3, 0, 2},
+		{5, 2, 2},
+	}
+
+	for _, test := range tests {
+		if actual := img.ColorIndexAt(test.x, test.y); actual != test.expected {
+			t.Errorf("%d,%d: expected %d, got %d", test.x, test.y, test.expected, actual)
+		}
+	}
+}
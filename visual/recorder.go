@@ -0,0 +1,34 @@
+package visual
+
+import "context"
+
+// Recorder collects the frames of a simulation. Once a frame has been
+// recorded, it belongs to the recorder and must not be changed.
+type Recorder interface {
+	Record(f *Frame)
+}
+
+// Reel is a Recorder that keeps every frame, in order.
+type Reel struct {
+	Frames []*Frame
+}
+
+type recorderKey struct{}
+
+// WithRecorder returns a copy of ctx that carries r, for a puzzler to record
+// frames to.
+func WithRecorder(ctx context.Context, r Recorder) context.Context {
+	return context.WithValue(ctx, recorderKey{}, r)
+}
+
+// RecorderFrom returns the Recorder carried by ctx, or nil if there isn't
+// one. Building frames takes time and memory, so puzzlers should only do it
+// when there is somewhere to record them.
+func RecorderFrom(ctx context.Context) Recorder {
+	r, _ := ctx.Value(recorderKey{}).(Recorder)
+	return r
+}
+
+func (r *Reel) Record(f *Frame) {
+	r.Frames = append(r.Frames, f)
+}
@@ -0,0 +1,26 @@
+package visual
+
+import (
+	"context"
+	"testing"
+)
+
+func TestRecorderFrom(t *testing.T) {
+	if rec := RecorderFrom(context.Background()); rec != nil {
+		t.Errorf("Expected no recorder, got %v", rec)
+	}
+
+	reel := &Reel{}
+	ctx := WithRecorder(context.Background(), reel)
+
+	RecorderFrom(ctx).Record(NewFrame(1, 1, testPalette))
+	RecorderFrom(ctx).Record(NewFrame(2, 2, testPalette))
+
+	if len(reel.Frames) != 2 {
+		t.Fatalf("Expected 2 frames, got %d", len(reel.Frames))
+	}
+
+	if reel.Frames[1].Width != 2 {
+		t.Errorf("Frames were recorded out of order")
+	}
+}
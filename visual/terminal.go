@@ -0,0 +1,64 @@
+package visual
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"time"
+)
+
+// ANSI escape sequences used to draw frames
+const (
+	clearScreen = "\033[H\033[2J"
+	resetStyle  = "\033[0m"
+)
+
+// Play draws frames one after another on w, which should be a terminal that
+// understands ANSI escape sequences, waiting delay between each. It stops
+// early with ctx.Err() if ctx is done first.
+func Play(ctx context.Context, w io.Writer, frames []*Frame, delay time.Duration) error {
+	for i, f := range frames {
+		if i > 0 {
+			select {
+			case <-time.After(delay):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		if err := drawFrame(w, f); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// drawFrame clears the screen and draws f in color, followed by its caption
+func drawFrame(w io.Writer, f *Frame) error {
+	b := bufio.NewWriter(w)
+
+	b.WriteString(clearScreen)
+
+	for y := 0; y < f.Height; y++ {
+		// only change color when it needs to, which keeps the output small
+		last := -1
+		for x := 0; x < f.Width; x++ {
+			index := int(f.At(x, y))
+			style := f.Palette[index]
+			if index != last {
+				fmt.Fprintf(b, "\033[38;2;%d;%d;%dm", style.Color.R, style.Color.G, style.Color.B)
+				last = index
+			}
+			b.WriteRune(style.Rune)
+		}
+		b.WriteString(resetStyle + "\n")
+	}
+
+	if f.Caption != "" {
+		b.WriteString(f.Caption + "\n")
+	}
+
+	return b.Flush()
+}
@@ -0,0 +1,55 @@
+package visual
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPlay(t *testing.T) {
+	first := NewFrame(2, 1, testPalette)
+	first.Caption = "step 0"
+	second := first.Copy()
+	second.Set(1, 0, 1)
+	second.Caption = "step 1"
+
+	var b bytes.Buffer
+	if err := Play(context.Background(), &b, []*Frame{first, second}, time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+
+	out := b.String()
+
+	if n := strings.Count(out, clearScreen); n != 2 {
+		t.Errorf("Expected the screen to be cleared twice, was %d times", n)
+	}
+
+	for _, expected := range []string{"step 0\n", "step 1\n", "\033[38;2;255;255;255m#"} {
+		if !strings.Contains(out, expected) {
+			t.Errorf("Expected output to contain %q, got %q", expected, out)
+		}
+	}
+}
+
+func TestPlayCanceled(t *testing.T) {
+	frames := []*Frame{
+		NewFrame(1, 1, testPalette),
+		NewFrame(1, 1, testPalette),
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var b bytes.Buffer
+	err := Play(ctx, &b, frames, time.Hour)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+
+	if n := strings.Count(b.String(), clearScreen); n != 1 {
+		t.Errorf("Expected only the first frame to be drawn, got %d", n)
+	}
+}